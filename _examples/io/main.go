@@ -30,12 +30,8 @@ func main() {
 		),
 	)
 
-	// create proxy reader
-	proxyReader := bar.ProxyReader(reader)
-	defer proxyReader.Close()
-
-	// copy from proxyReader, ignoring errors
-	io.Copy(ioutil.Discard, proxyReader)
+	// copy from reader, bar is completed on success or aborted on error
+	mpb.Copy(ioutil.Discard, reader, bar)
 
 	p.Wait()
 }
@@ -0,0 +1,114 @@
+package mpb
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/vbauerster/mpb/v5/decor"
+)
+
+// announcer writes plain sentences about bars' progress, which screen
+// readers can follow, at most once per interval.
+type announcer struct {
+	w         io.Writer
+	interval  time.Duration
+	last      time.Time
+	completed map[int]bool
+	pending   []decor.Statistics
+	err       error
+}
+
+// update stores stats of rendered bars, and announces them, if
+// interval has passed since the last announcement.
+func (a *announcer) update(stats []decor.Statistics) {
+	a.pending = append(a.pending[:0], stats...)
+	if time.Since(a.last) >= a.interval {
+		a.flush()
+	}
+}
+
+// flush announces pending stats, if any, and returns the first write
+// error encountered so far. Completed and aborted bars are announced
+// once.
+func (a *announcer) flush() error {
+	if len(a.pending) == 0 || a.err != nil {
+		return a.err
+	}
+	if a.completed == nil {
+		a.completed = make(map[int]bool)
+	}
+	a.last = time.Now()
+	var sb strings.Builder
+	for _, st := range a.pending {
+		if a.completed[st.ID] {
+			continue
+		}
+		a.completed[st.ID] = st.Completed || st.Aborted
+		sb.WriteString(announce(st))
+		sb.WriteByte('\n')
+	}
+	a.pending = a.pending[:0]
+	if sb.Len() != 0 {
+		_, a.err = io.WriteString(a.w, sb.String())
+	}
+	return a.err
+}
+
+// announce makes a sentence like "download 45 percent, 2 minutes
+// remaining".
+func announce(st decor.Statistics) string {
+	name := st.Name
+	if name == "" {
+		name = fmt.Sprintf("bar %d", st.ID)
+	}
+	if st.Aborted {
+		return name + " aborted"
+	}
+	if st.Completed {
+		return name + " complete"
+	}
+	if st.Total <= 0 {
+		return name + " in progress"
+	}
+	pct := st.Current * 100 / st.Total
+	msg := fmt.Sprintf("%s %d percent", name, pct)
+	if st.Current > 0 && st.ActiveElapsed > 0 {
+		perItem := st.ActiveElapsed.Seconds() / float64(st.Current)
+		remaining := time.Duration(perItem * float64(st.Total-st.Current) * float64(time.Second))
+		msg += ", " + spellDuration(remaining) + " remaining"
+	}
+	return msg
+}
+
+// spellDuration spells d in words with precision of two largest
+// units, like "1 hour 5 minutes" or "45 seconds".
+func spellDuration(d time.Duration) string {
+	units := [...]struct {
+		name string
+		dur  time.Duration
+	}{
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+		{"second", time.Second},
+	}
+	d = d.Round(time.Second)
+	var parts []string
+	for _, u := range units {
+		n := int64(d / u.dur)
+		if n == 0 && !(len(parts) == 0 && u.dur == time.Second) {
+			continue
+		}
+		d -= time.Duration(n) * u.dur
+		word := u.name
+		if n != 1 {
+			word += "s"
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", n, word))
+		if len(parts) == 2 {
+			break
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,44 @@
+package mpb
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/vbauerster/mpb/v5/decor"
+)
+
+func TestSpellDuration(t *testing.T) {
+	cases := map[time.Duration]string{
+		0:                                    "0 seconds",
+		time.Second:                          "1 second",
+		45 * time.Second:                     "45 seconds",
+		2*time.Minute + 400*time.Millisecond: "2 minutes",
+		time.Hour + 5*time.Minute + 3*time.Second: "1 hour 5 minutes",
+		26 * time.Hour: "26 hours",
+	}
+	for d, want := range cases {
+		if got := spellDuration(d); got != want {
+			t.Errorf("%s: want: %q, got: %q", d, want, got)
+		}
+	}
+}
+
+func TestAnnouncerFinalOnce(t *testing.T) {
+	var buf bytes.Buffer
+	a := &announcer{w: &buf}
+	for i := 0; i < 3; i++ {
+		a.update([]decor.Statistics{
+			{ID: 0, Name: "a", Total: 10, Current: 10, Completed: true},
+			{ID: 1, Name: "b", Total: 10, Current: 5, Aborted: true},
+		})
+	}
+	if err := a.flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "a complete\nb aborted\n"
+	if got := buf.String(); got != want {
+		t.Errorf("want: %q, got: %q", want, got)
+	}
+}
@@ -5,9 +5,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
+	"math"
 	"runtime/debug"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/acarl005/stripansi"
@@ -21,10 +24,21 @@ type Bar struct {
 	index    int // used by heap
 
 	extendedLines     int
+	silent            bool
+	idle              bool
+	idleTicks         int
+	frameWidth        int
+	lastFrame         []byte
+	dirty             int32
+	stale             int32
+	lastStat          decor.Statistics
 	toShutdown        bool
 	toDrop            bool
 	noPop             bool
+	header            bool
+	pull              bool
 	hasEwmaDecorators bool
+	scale             float64
 	operateState      chan func(*bState)
 	frameCh           chan io.Reader
 	syncTableCh       chan [][]chan int
@@ -46,24 +60,48 @@ type extFunc func(in io.Reader, reqWidth int, st decor.Statistics) (out io.Reade
 
 type bState struct {
 	id                int
+	name              string
+	attempt           int
 	priority          int
 	reqWidth          int
+	compactMin        int
+	scale             float64
+	scaleRem          float64
 	total             int64
 	current           int64
 	refill            int64
+	overflow          int64
+	errors            int64
 	lastN             int64
+	lastUpdate        time.Time
+	startTime         time.Time
+	stopTime          time.Time
+	pausedAt          time.Time
+	pausedTotal       time.Duration
 	iterated          bool
 	trimSpace         bool
 	toComplete        bool
 	completeFlushed   bool
 	ignoreComplete    bool
+	noClamp           bool
+	silent            bool
 	dropOnComplete    bool
 	noPop             bool
+	header            bool
+	asciiEllipsis     bool
+	tickFunc          func(decor.Statistics) int64
+	shortFill         ShortFill
+	pending           bool
+	separator         string
+	metrics           decor.Metrics
+	aborted           bool
 	aDecorators       []decor.Decorator
 	pDecorators       []decor.Decorator
 	averageDecorators []decor.AverageDecorator
 	ewmaDecorators    []decor.EwmaDecorator
 	shutdownListeners []decor.ShutdownListener
+	progressHooks     []*progressHook
+	incrListeners     []func(n int64, now time.Time)
 	bufP, bufB, bufA  *bytes.Buffer
 	filler            BarFiller
 	middleware        func(BarFiller) BarFiller
@@ -73,6 +111,13 @@ type bState struct {
 	runningBar *Bar
 
 	debugOut io.Writer
+	term     Terminal
+}
+
+type progressHook struct {
+	threshold float64
+	fn        func()
+	fired     bool
 }
 
 func newBar(container *Progress, bs *bState) *Bar {
@@ -84,6 +129,9 @@ func newBar(container *Progress, bs *bState) *Bar {
 		priority:     bs.priority,
 		toDrop:       bs.dropOnComplete,
 		noPop:        bs.noPop,
+		header:       bs.header,
+		pull:         bs.tickFunc != nil,
+		scale:        bs.scale,
 		operateState: make(chan func(*bState)),
 		frameCh:      make(chan io.Reader, 1),
 		syncTableCh:  make(chan [][]chan int, 1),
@@ -103,7 +151,75 @@ func (b *Bar) ProxyReader(r io.Reader) io.ReadCloser {
 	if r == nil {
 		panic("expected non nil io.Reader")
 	}
-	return newProxyReader(r, b)
+	return newProxyReader(r, b, newIterClock(), eofCountdown(b, 1)())
+}
+
+// ProxyReaderLimited is ProxyReader, which throttles reads from r to
+// bytesPerSec, so bandwidth limited transfer gets both throttling and
+// progress tracking from one wrapper. Time spent waiting is accounted
+// as iteration time, so speed decorators reflect the cap. Zero or
+// negative bytesPerSec means no limit. Panics if r is nil.
+func (b *Bar) ProxyReaderLimited(r io.Reader, bytesPerSec int64) io.ReadCloser {
+	if r == nil {
+		panic("expected non nil io.Reader")
+	}
+	if bytesPerSec > 0 {
+		r = newRateLimitedReader(r, bytesPerSec)
+	}
+	return newProxyReader(r, b, newIterClock(), eofCountdown(b, 1)())
+}
+
+// ProxyReaderWithSizeHint is ProxyReader for streams, which size is
+// discovered mid-stream, for example after reading a header. Hint is
+// called after each read, until it returns positive size, which is
+// set as bar's total then. Until that bar's total is treated as
+// unknown, so it doesn't complete early. Use it together with
+// NewAutoFiller, to switch from spinner to a regular bar
+// automatically. Panics if r is nil.
+func (b *Bar) ProxyReaderWithSizeHint(r io.Reader, hint func() int64) io.ReadCloser {
+	rc := b.ProxyReader(r)
+	if hint == nil {
+		return rc
+	}
+	select {
+	case b.operateState <- func(s *bState) {
+		if s.total <= 0 {
+			s.ignoreComplete = true
+		}
+	}:
+	case <-b.done:
+	}
+	return &sizeHintReader{rc, b, hint, false}
+}
+
+// ProxyReaderN wraps each of rs with metrics required for progress
+// tracking, so several readers can be consumed concurrently while
+// driving the same bar. Bar completes once every reader has reached
+// io.EOF. Panics if any of rs is nil.
+func (b *Bar) ProxyReaderN(rs ...io.Reader) []io.ReadCloser {
+	clock := newIterClock()
+	countdown := eofCountdown(b, len(rs))
+	rcs := make([]io.ReadCloser, len(rs))
+	for i, r := range rs {
+		if r == nil {
+			panic("expected non nil io.Reader")
+		}
+		rcs[i] = newProxyReader(r, b, clock, countdown())
+	}
+	return rcs
+}
+
+// NewCounter returns io.Writer, which increments bar by amount of
+// bytes written into it. It's safe for concurrent use, so several
+// goroutines may report their progress into the same counter, for
+// example with help of io.TeeReader. Completion is up to the caller,
+// either by reaching total or by *Bar.SetTotal(-1, true).
+func (b *Bar) NewCounter() io.Writer {
+	c := &counter{bar: b}
+	if b.hasEwmaDecorators {
+		c.clock = newIterClock()
+	}
+	return c
 }
 
 // ID returs id of the bar.
@@ -169,11 +285,12 @@ func (b *Bar) SetTotal(total int64, complete bool) {
 		} else {
 			s.total = total
 		}
-		if !s.ignoreComplete && !s.toComplete {
+		if !s.ignoreComplete && s.current < s.total {
 			s.current = s.total
-			s.toComplete = true
-			go b.refreshTillShutdown()
 		}
+		b.checkComplete(s, false)
+		s.fireProgressHooks()
+		b.container.notifyUpdate()
 	}:
 	case <-b.done:
 	}
@@ -184,14 +301,161 @@ func (b *Bar) SetTotal(total int64, complete bool) {
 func (b *Bar) SetCurrent(current int64) {
 	select {
 	case b.operateState <- func(s *bState) {
+		if s.pending {
+			s.start(time.Now())
+		}
 		s.iterated = true
+		s.lastUpdate = time.Now()
 		s.lastN = current - s.current
 		s.current = current
-		if !s.ignoreComplete && s.current >= s.total {
-			s.current = s.total
-			s.toComplete = true
-			go b.refreshTillShutdown()
+		b.checkComplete(s, true)
+		s.fireProgressHooks()
+		b.container.notifyUpdate()
+	}:
+	case <-b.done:
+	}
+}
+
+// tick sets current, pulled by BarTickFunc, within render operation.
+func (b *Bar) tick(s *bState, current int64) {
+	if current == s.current {
+		return
+	}
+	if s.pending {
+		s.start(time.Now())
+	}
+	s.iterated = true
+	s.lastUpdate = time.Now()
+	s.lastN = current - s.current
+	s.current = current
+	b.checkComplete(s, true)
+	s.fireProgressHooks()
+}
+
+// checkComplete clamps current at total, unless BarNoClamp option is
+// set, and triggers complete event exactly once. If current was set
+// to absolute value, overflow is set from it, otherwise clamped amount
+// adds up to overflow.
+func (b *Bar) checkComplete(s *bState, absolute bool) {
+	if s.ignoreComplete || s.current < s.total {
+		return
+	}
+	if !s.noClamp {
+		if absolute {
+			s.overflow = s.current - s.total
+		} else {
+			s.overflow += s.current - s.total
+		}
+		s.current = s.total
+	}
+	if !s.toComplete {
+		s.toComplete = true
+		s.stop(time.Now())
+		go b.refreshTillShutdown()
+	}
+}
+
+// Pause stops bar's active time accounting, until Resume is called.
+// Active time is exposed via decor.Statistics.ActiveElapsed and is
+// used by pause-aware decorators, like decor.ActiveElapsed.
+func (b *Bar) Pause() {
+	select {
+	case b.operateState <- func(s *bState) {
+		if s.pausedAt.IsZero() && s.stopTime.IsZero() {
+			s.pausedAt = time.Now()
+		}
+	}:
+	case <-b.done:
+	}
+}
+
+// Resume resumes bar's active time accounting, after Pause.
+func (b *Bar) Resume() {
+	select {
+	case b.operateState <- func(s *bState) {
+		if !s.pausedAt.IsZero() {
+			s.pausedTotal += time.Since(s.pausedAt)
+			s.pausedAt = time.Time{}
+		}
+	}:
+	case <-b.done:
+	}
+}
+
+// suspended excludes dur from bar's active time. It's called by the
+// container, when process suspension is detected.
+func (b *Bar) suspended(dur time.Duration) {
+	select {
+	case b.operateState <- func(s *bState) {
+		if s.pausedAt.IsZero() && s.stopTime.IsZero() {
+			s.pausedTotal += dur
+		}
+	}:
+	case <-b.done:
+	}
+}
+
+// Start moves bar out of pending state, see BarPending option. Time
+// accounting and average based decorators start from now. It has no
+// effect, if bar isn't pending.
+func (b *Bar) Start() {
+	select {
+	case b.operateState <- func(s *bState) {
+		if s.pending {
+			s.start(time.Now())
+			b.container.notifyUpdate()
+		}
+	}:
+	case <-b.done:
+	}
+}
+
+// Reset starts bar over with new total, so a retried task may reuse
+// the same line instead of stacking new bars. Current, refill,
+// overflow and error counter are zeroed, time accounting, average and
+// moving average based decorators are reset, and attempt number,
+// exposed via decor.Statistics.Attempt, is incremented. It has no
+// effect after completion event.
+func (b *Bar) Reset(total int64) {
+	select {
+	case b.operateState <- func(s *bState) {
+		if s.toComplete {
+			return
+		}
+		now := time.Now()
+		s.attempt++
+		s.total = total
+		s.current = 0
+		s.refill = 0
+		s.overflow = 0
+		s.errors = 0
+		s.lastN = 0
+		s.scaleRem = 0
+		s.iterated = false
+		s.lastUpdate = now
+		s.startTime = now
+		s.stopTime = time.Time{}
+		s.pausedTotal = 0
+		if !s.pausedAt.IsZero() {
+			s.pausedAt = now
+		}
+		for _, h := range s.progressHooks {
+			h.fired = false
 		}
+		for _, d := range s.averageDecorators {
+			d.AverageAdjust(now)
+		}
+		for _, decorators := range [...][]decor.Decorator{
+			s.pDecorators,
+			s.aDecorators,
+		} {
+			for _, d := range decorators {
+				if d, ok := extractBaseDecorator(d).(decor.ResetDecorator); ok {
+					d.Reset()
+				}
+			}
+		}
+		b.container.notifyUpdate()
 	}:
 	case <-b.done:
 	}
@@ -211,19 +475,115 @@ func (b *Bar) IncrBy(n int) {
 func (b *Bar) IncrInt64(n int64) {
 	select {
 	case b.operateState <- func(s *bState) {
-		s.iterated = true
-		s.lastN = n
-		s.current += n
-		if !s.ignoreComplete && s.current >= s.total {
-			s.current = s.total
-			s.toComplete = true
-			go b.refreshTillShutdown()
+		b.increment(s, n)
+	}:
+	case <-b.done:
+	}
+}
+
+// increment is a common part of Incr... family methods, it's called
+// within bar's state operation.
+func (b *Bar) increment(s *bState, n int64) {
+	now := time.Now()
+	if s.pending {
+		s.start(now)
+	}
+	s.iterated = true
+	s.lastUpdate = now
+	s.lastN = n
+	s.current += n
+	for _, fn := range s.incrListeners {
+		fn(n, now)
+	}
+	b.checkComplete(s, false)
+	s.fireProgressHooks()
+	b.container.notifyUpdate()
+}
+
+// IncrError increments bar's error counter by n, so batch processors
+// can show partial failure counts, see decor.Errors. Error counter
+// doesn't affect progress and is zeroed by *Bar.Reset.
+func (b *Bar) IncrError(n int) {
+	select {
+	case b.operateState <- func(s *bState) {
+		s.errors += int64(n)
+		b.container.notifyUpdate()
+	}:
+	case <-b.done:
+	}
+}
+
+// SetTotalFloat is SetTotal for bars with fractional progress, total
+// is scaled by BarFloatScale option.
+func (b *Bar) SetTotalFloat(total float64, complete bool) {
+	b.SetTotal(int64(math.Round(total*b.scale)), complete)
+}
+
+// SetCurrentFloat is SetCurrent for bars with fractional progress,
+// current is scaled by BarFloatScale option.
+func (b *Bar) SetCurrentFloat(current float64) {
+	b.SetCurrent(int64(math.Round(current * b.scale)))
+}
+
+// IncrFloat64 increments progress by fractional amount of n, scaled by
+// BarFloatScale option. Fraction which doesn't fit into the scale is
+// carried over to the next call, so many small increments add up.
+func (b *Bar) IncrFloat64(n float64) {
+	select {
+	case b.operateState <- func(s *bState) {
+		v := n*s.scale + s.scaleRem
+		whole := math.Trunc(v)
+		if r := math.Round(v); math.Abs(v-r) < 1e-9 {
+			// float error tolerance, so 10 times 0.1 adds up to 1
+			whole = r
 		}
+		s.scaleRem = v - whole
+		b.increment(s, int64(whole))
+	}:
+	case <-b.done:
+	}
+}
+
+// EwmaIncrBy is a shorthand for b.EwmaIncrInt64(int64(n), iterDur).
+func (b *Bar) EwmaIncrBy(n int, iterDur time.Duration) {
+	b.EwmaIncrInt64(int64(n), iterDur)
+}
+
+// EwmaIncrInt64 increments progress by amount of n and updates EWMA
+// based decorators by dur of a single iteration. Unlike IncrInt64
+// followed by DecoratorEwmaUpdate it's done atomically, so it's safe
+// to call from several goroutines concurrently.
+func (b *Bar) EwmaIncrInt64(n int64, iterDur time.Duration) {
+	select {
+	case b.operateState <- func(s *bState) {
+		b.increment(s, n)
+		ewmaIterationUpdate(false, s, iterDur)
 	}:
 	case <-b.done:
 	}
 }
 
+// OnProgress registers fn to be called once, when progress crosses
+// threshold fraction of total, for example 0.5 for 50%. The fn is
+// called in its own goroutine. If progress has already crossed
+// threshold, fn is called right away.
+func (b *Bar) OnProgress(threshold float64, fn func()) {
+	if fn == nil {
+		return
+	}
+	hook := &progressHook{threshold: threshold, fn: fn}
+	select {
+	case b.operateState <- func(s *bState) {
+		s.progressHooks = append(s.progressHooks, hook)
+		s.fireProgressHooks()
+	}:
+	case <-b.done:
+		if b.cacheState.progress() >= threshold {
+			go fn()
+		}
+	}
+}
+
 // DecoratorEwmaUpdate updates all EWMA based decorators. Should be
 // called on each iteration, because EWMA's unit of measure is an
 // iteration's duration. Panics if called before *Bar.Incr... family
@@ -277,6 +637,28 @@ func (b *Bar) Abort(drop bool) {
 	}
 }
 
+// Wait blocks until bar has completed or has been aborted, so pipeline
+// stages can synchronize on upstream bar without extra channels. Bar
+// is shut down by container right after its final frame has been
+// rendered, so Wait returns only after that. Unlike BarQueueAfter,
+// which replaces bar visually, Wait is meant for program flow.
+func (b *Bar) Wait() {
+	<-b.done
+}
+
+// SetSilent hides bar from output, or shows it again, at runtime.
+// Silent bar keeps its state, position and decorators updating, so
+// verbose modes can toggle visibility without recreating bars. It
+// has no effect after bar's shutdown.
+func (b *Bar) SetSilent(silent bool) {
+	select {
+	case b.operateState <- func(s *bState) {
+		s.silent = silent
+	}:
+	case <-b.done:
+	}
+}
+
 // Completed reports whether the bar is in completed state.
 func (b *Bar) Completed() bool {
 	select {
@@ -287,13 +669,29 @@ func (b *Bar) Completed() bool {
 	}
 }
 
+func (b *Bar) statistics() decor.Statistics {
+	result := make(chan decor.Statistics)
+	select {
+	case b.operateState <- func(s *bState) { result <- newStatistics(0, s) }:
+		return <-result
+	case <-b.done:
+		return newStatistics(0, b.cacheState)
+	}
+}
+
 func (b *Bar) serve(ctx context.Context, s *bState) {
-	defer b.container.bwg.Done()
+	if !b.header {
+		defer b.container.bwg.done()
+	}
 	for {
 		select {
 		case op := <-b.operateState:
+			atomic.StoreInt32(&b.dirty, 1)
 			op(s)
 		case <-ctx.Done():
+			atomic.StoreInt32(&b.dirty, 1)
+			s.aborted = !s.toComplete
+			s.stop(time.Now())
 			b.cacheState = s
 			close(b.done)
 			// Notifying decorators about shutdown event
@@ -308,7 +706,12 @@ func (b *Bar) serve(ctx context.Context, s *bState) {
 func (b *Bar) render(tw int) {
 	select {
 	case b.operateState <- func(s *bState) {
+		atomic.StoreInt32(&b.dirty, 0)
+		// stat is the frame's snapshot, taken within serialized state
+		// operation, increments queued meanwhile land in next frame
 		stat := newStatistics(tw, s)
+		b.lastStat = stat
+		b.silent = s.silent
 		defer func() {
 			// recovering if user defined decorator panics for example
 			if p := recover(); p != nil {
@@ -324,17 +727,24 @@ func (b *Bar) render(tw int) {
 			}
 			s.completeFlushed = s.toComplete
 		}()
-		frame, lines := s.extender(s.draw(stat), s.reqWidth, stat)
+		if s.tickFunc != nil && !s.toComplete {
+			b.tick(s, s.tickFunc(stat))
+			stat = newStatistics(tw, s)
+			b.lastStat = stat
+		}
+		frame, lines := s.extender(s.dimPending(s.draw(stat)), s.reqWidth, stat)
 		b.extendedLines = lines
 		b.toShutdown = s.toComplete && !s.completeFlushed
 		b.frameCh <- frame
 	}:
 	case <-b.done:
+		atomic.StoreInt32(&b.dirty, 0)
 		s := b.cacheState
 		stat := newStatistics(tw, s)
+		b.lastStat = stat
 		var r io.Reader
 		if b.recoveredPanic == nil {
-			r = s.draw(stat)
+			r = s.dimPending(s.draw(stat))
 		}
 		frame, lines := s.extender(r, s.reqWidth, stat)
 		b.extendedLines = lines
@@ -342,6 +752,20 @@ func (b *Bar) render(tw int) {
 	}
 }
 
+// canReuseFrame reports whether last frame may be flushed instead of
+// rendering a new one, see WithIdleRefresh. Called by container only.
+func (b *Bar) canReuseFrame(tw, budget int) bool {
+	stale := atomic.SwapInt32(&b.stale, 0) == 1
+	return b.lastFrame != nil &&
+		!stale &&
+		!b.toShutdown &&
+		b.recoveredPanic == nil &&
+		!b.pull &&
+		b.frameWidth == tw &&
+		b.idleTicks < budget-1 &&
+		atomic.LoadInt32(&b.dirty) == 0
+}
+
 func (b *Bar) subscribeDecorators() {
 	var averageDecorators []decor.AverageDecorator
 	var ewmaDecorators []decor.EwmaDecorator
@@ -388,6 +812,9 @@ func (b *Bar) wSyncTable() [][]chan int {
 }
 
 func (s *bState) draw(stat decor.Statistics) io.Reader {
+	if s.compactMin > 0 {
+		return s.drawCompact(stat)
+	}
 	if !s.trimSpace {
 		stat.AvailableWidth -= 2
 		s.bufB.WriteByte(' ')
@@ -396,34 +823,351 @@ func (s *bState) draw(stat decor.Statistics) io.Reader {
 
 	nlr := strings.NewReader("\n")
 	tw := stat.AvailableWidth
-	for _, d := range s.pDecorators {
-		str := d.Decor(stat)
-		stat.AvailableWidth -= runewidth.StringWidth(stripansi.Strip(str))
-		s.bufP.WriteString(str)
-	}
+	sepWidth := s.metrics.StringWidth(stripansi.Strip(s.separator))
+	s.drawDecorators(s.bufP, s.pDecorators, &stat, sepWidth)
 	if stat.AvailableWidth <= 0 {
-		trunc := strings.NewReader(runewidth.Truncate(stripansi.Strip(s.bufP.String()), tw, "…"))
+		trunc := strings.NewReader(s.truncate(stripansi.Strip(s.bufP.String()), tw))
 		s.bufP.Reset()
 		return io.MultiReader(trunc, s.bufB, nlr)
 	}
 
 	tw = stat.AvailableWidth
-	for _, d := range s.aDecorators {
-		str := d.Decor(stat)
-		stat.AvailableWidth -= runewidth.StringWidth(stripansi.Strip(str))
-		s.bufA.WriteString(str)
-	}
+	s.drawDecorators(s.bufA, s.aDecorators, &stat, sepWidth)
 	if stat.AvailableWidth <= 0 {
-		trunc := strings.NewReader(runewidth.Truncate(stripansi.Strip(s.bufA.String()), tw, "…"))
+		trunc := strings.NewReader(s.truncate(stripansi.Strip(s.bufA.String()), tw))
 		s.bufA.Reset()
 		return io.MultiReader(s.bufP, s.bufB, trunc, nlr)
 	}
 
-	s.filler.Fill(s.bufB, s.reqWidth, stat)
+	s.fill(stat)
 
 	return io.MultiReader(s.bufP, s.bufB, s.bufA, nlr)
 }
 
+// drawDecorators renders decorators of one side into buf. Separator is
+// decided once decorator has rendered, so there is no separator next
+// to decorator, which renders nothing.
+func (s *bState) drawDecorators(buf *bytes.Buffer, decorators []decor.Decorator, stat *decor.Statistics, sepWidth int) {
+	var prev decor.Decorator
+	for _, d := range decorators {
+		str := s.metrics.ExpandTabs(d.Decor(*stat))
+		width := s.metrics.StringWidth(stripansi.Strip(str))
+		if width == 0 {
+			buf.WriteString(str)
+			continue
+		}
+		if prev != nil && sepWidth != 0 && separated(prev, d) {
+			stat.AvailableWidth -= sepWidth
+			buf.WriteString(s.separator)
+		}
+		stat.AvailableWidth -= width
+		buf.WriteString(str)
+		prev = d
+	}
+}
+
+// drawCompact is draw for compact layout, it drops decorators by their
+// drop priority, until there is room for at least s.compactMin wide bar.
+func (s *bState) drawCompact(stat decor.Statistics) io.Reader {
+	if !s.trimSpace {
+		stat.AvailableWidth -= 2
+		s.bufB.WriteByte(' ')
+		defer s.bufB.WriteByte(' ')
+	}
+
+	type column struct {
+		str       string
+		width     int
+		priority  int
+		droppable bool
+		dropped   bool
+		append    bool
+		literal   bool
+	}
+
+	nlr := strings.NewReader("\n")
+	tw := stat.AvailableWidth
+	columns := make([]column, 0, len(s.pDecorators)+len(s.aDecorators))
+	for i, decorators := range [...][]decor.Decorator{
+		s.pDecorators,
+		s.aDecorators,
+	} {
+		for _, d := range decorators {
+			_, literal := d.(layoutText)
+			col := column{str: s.metrics.ExpandTabs(d.Decor(stat)), append: i == 1, literal: literal}
+			col.width = s.metrics.StringWidth(stripansi.Strip(col.str))
+			col.priority, col.droppable = dropPriority(d)
+			columns = append(columns, col)
+		}
+	}
+
+	// separators go in between kept columns of the same side, unless
+	// one of them is layout text, zero width columns are skipped
+	sepWidth := s.metrics.StringWidth(stripansi.Strip(s.separator))
+	side := func(col column) int {
+		if col.append {
+			return 1
+		}
+		return 0
+	}
+	used := func() int {
+		var width int
+		var prev [2]*column
+		for i, col := range columns {
+			if col.dropped || col.width == 0 {
+				continue
+			}
+			if p := prev[side(col)]; p != nil && !p.literal && !col.literal {
+				width += sepWidth
+			}
+			prev[side(col)] = &columns[i]
+			width += col.width
+		}
+		return width
+	}
+	stat.AvailableWidth = tw - used()
+
+	for stat.AvailableWidth < s.compactMin {
+		victim := -1
+		for i, col := range columns {
+			if !col.droppable || col.dropped {
+				continue
+			}
+			if victim < 0 || col.priority >= columns[victim].priority {
+				victim = i
+			}
+		}
+		if victim < 0 {
+			break
+		}
+		columns[victim].dropped = true
+		stat.AvailableWidth = tw - used()
+	}
+
+	var prev [2]*column
+	for i, col := range columns {
+		if col.dropped {
+			continue
+		}
+		buf := s.bufP
+		if col.append {
+			buf = s.bufA
+		}
+		if col.width == 0 {
+			buf.WriteString(col.str)
+			continue
+		}
+		if p := prev[side(col)]; p != nil && !p.literal && !col.literal && sepWidth != 0 {
+			buf.WriteString(s.separator)
+		}
+		prev[side(col)] = &columns[i]
+		buf.WriteString(col.str)
+	}
+
+	if stat.AvailableWidth <= 0 {
+		str := stripansi.Strip(s.bufP.String() + s.bufA.String())
+		trunc := strings.NewReader(s.truncate(str, tw))
+		s.bufP.Reset()
+		s.bufA.Reset()
+		return io.MultiReader(trunc, s.bufB, nlr)
+	}
+
+	s.fill(stat)
+
+	return io.MultiReader(s.bufP, s.bufB, s.bufA, nlr)
+}
+
+// fill draws filler into bufB, honoring ShortFill mode of aborted bar.
+func (s *bState) fill(stat decor.Statistics) {
+	if stat.Aborted && s.shortFill != ShortFillFreeze {
+		if f, ok := s.filler.(shortFiller); ok && f.fillShort(s.bufB, s.reqWidth, stat, s.shortFill) {
+			return
+		}
+		if s.shortFill == ShortFillFull {
+			stat.Current = stat.Total
+		}
+	}
+	s.filler.Fill(s.bufB, s.reqWidth, stat)
+}
+
+func (s *bState) progress() float64 {
+	if s.total <= 0 {
+		return 0
+	}
+	return float64(s.current) / float64(s.total)
+}
+
+// stop freezes bar's elapsed time accounting.
+func (s *bState) stop(now time.Time) {
+	if !s.stopTime.IsZero() {
+		return
+	}
+	if !s.pausedAt.IsZero() {
+		s.pausedTotal += now.Sub(s.pausedAt)
+		s.pausedAt = time.Time{}
+	}
+	s.stopTime = now
+}
+
+// start moves bar out of pending state.
+func (s *bState) start(now time.Time) {
+	s.pending = false
+	s.startTime = now
+	s.lastUpdate = now
+	for _, d := range s.averageDecorators {
+		d.AverageAdjust(now)
+	}
+}
+
+// dimPending renders line of pending bar dimmed, if terminal supports
+// it. Line is expected to end with newline. Dim is re-enabled after
+// every SGR sequence, which resets intensity, like colored decorators
+// or filler emit.
+func (s *bState) dimPending(r io.Reader) io.Reader {
+	if !s.pending || !s.term.IsTTY || !s.term.VT {
+		return r
+	}
+	line, _ := ioutil.ReadAll(r)
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	return io.MultiReader(
+		strings.NewReader(sgrDim),
+		bytes.NewReader(redim(line)),
+		strings.NewReader(sgrNormal+"\n"),
+	)
+}
+
+// redim inserts sgrDim after every SGR sequence of line, which resets
+// intensity, i.e. has no parameters or has 0 or 22 parameter.
+func redim(line []byte) []byte {
+	var out []byte
+	var last int
+	for i := 0; i < len(line); i++ {
+		if line[i] != '\x1b' || i+1 == len(line) || line[i+1] != '[' {
+			continue
+		}
+		j := i + 2
+		for j < len(line) && (line[j] < 0x40 || line[j] > 0x7e) {
+			j++
+		}
+		if j == len(line) {
+			break
+		}
+		if line[j] == 'm' && resetsIntensity(string(line[i+2:j])) {
+			out = append(out, line[last:j+1]...)
+			out = append(out, sgrDim...)
+			last = j + 1
+		}
+		i = j
+	}
+	if out == nil {
+		return line
+	}
+	return append(out, line[last:]...)
+}
+
+func resetsIntensity(params string) bool {
+	for _, p := range strings.Split(params, ";") {
+		switch strings.TrimLeft(p, "0") {
+		case "", "22":
+			return true
+		}
+	}
+	return false
+}
+
+// elapsed returns wall and active time passed since bar's start,
+// pending bar hasn't started yet.
+func (s *bState) elapsed() (wall, active time.Duration) {
+	if s.pending {
+		return 0, 0
+	}
+	now := s.stopTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+	wall = now.Sub(s.startTime)
+	active = wall - s.pausedTotal
+	if !s.pausedAt.IsZero() {
+		active -= now.Sub(s.pausedAt)
+	}
+	if active < 0 {
+		active = 0
+	}
+	return wall, active
+}
+
+func (s *bState) fireProgressHooks() {
+	if len(s.progressHooks) == 0 || s.total <= 0 {
+		return
+	}
+	progress := s.progress()
+	for _, h := range s.progressHooks {
+		if !h.fired && progress >= h.threshold {
+			h.fired = true
+			go h.fn()
+		}
+	}
+}
+
+// applyMetrics passes container's Metrics to decorators, which don't
+// set their own one.
+func (s *bState) applyMetrics() {
+	if s.metrics.IsZero() {
+		return
+	}
+	for _, decorators := range [...][]decor.Decorator{
+		s.pDecorators,
+		s.aDecorators,
+	} {
+		for _, d := range decorators {
+			if conf := d.GetConf(); conf.M.IsZero() {
+				conf.M = s.metrics
+				d.SetConf(conf)
+			}
+		}
+	}
+}
+
+func (s *bState) adaptTerminal() {
+	s.asciiEllipsis = !s.term.Unicode
+	if t, ok := s.filler.(TerminalAdapter); ok {
+		t.AdaptTerminal(s.term)
+	}
+	for _, decorators := range [...][]decor.Decorator{
+		s.pDecorators,
+		s.aDecorators,
+	} {
+		for _, d := range decorators {
+			base := extractBaseDecorator(d)
+			if t, ok := base.(TerminalAdapter); ok {
+				t.AdaptTerminal(s.term)
+			}
+			if a, ok := base.(decor.ASCIIAdapter); ok && !s.term.Unicode {
+				a.AdaptASCII()
+			}
+		}
+	}
+}
+
+// ellipsis returns truncation tail, which terminal can render.
+func (s *bState) ellipsis() string {
+	if s.asciiEllipsis {
+		return "..."
+	}
+	return "…"
+}
+
+// truncate truncates line, which doesn't fit into w cells, measuring
+// width with s.metrics. If w is narrower than ellipsis, line is
+// replaced with ellipsis.
+func (s *bState) truncate(line string, w int) string {
+	tail := s.ellipsis()
+	if s.metrics.StringWidth(line) > w && s.metrics.StringWidth(tail) > w {
+		return tail
+	}
+	return s.metrics.TruncateString(line, w, tail)
+}
+
 func (s *bState) wSyncTable() [][]chan int {
 	columns := make([]chan int, 0, len(s.pDecorators)+len(s.aDecorators))
 	var pCount int
@@ -447,13 +1191,45 @@ func (s *bState) wSyncTable() [][]chan int {
 }
 
 func newStatistics(tw int, s *bState) decor.Statistics {
+	elapsed, active := s.elapsed()
 	return decor.Statistics{
 		ID:             s.id,
+		Name:           s.name,
 		AvailableWidth: tw,
 		Total:          s.total,
 		Current:        s.current,
 		Refill:         s.refill,
+		Overflow:       s.overflow + max64(s.current-s.total, 0),
+		Errors:         s.errors,
+		LastUpdate:     s.lastUpdate,
+		Elapsed:        elapsed,
+		ActiveElapsed:  active,
+		Paused:         !s.pausedAt.IsZero(),
+		Attempt:        s.attempt,
+		Scale:          s.scale,
 		Completed:      s.completeFlushed,
+		Aborted:        s.aborted,
+		Pending:        s.pending,
+	}
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func dropPriority(d decor.Decorator) (int, bool) {
+	for {
+		if dd, ok := d.(decor.Dropper); ok {
+			return dd.DropPriority(), true
+		}
+		w, ok := d.(decor.Wrapper)
+		if !ok {
+			return 0, false
+		}
+		d = w.Base()
 	}
 }
 
@@ -17,6 +17,8 @@ import (
 //
 //	func NewBarFiller(style string, reverse bool) BarFiller
 //	func NewSpinnerFiller(style []string, alignment SpinnerAlignment) BarFiller
+//	func NewProgressiveBarFiller(style string, reverse bool) BarFiller
+//	func NewAutoFiller(spinnerStyle []string, barStyle string, reverse bool) BarFiller
 //
 type BarFiller interface {
 	Fill(w io.Writer, reqWidth int, stat decor.Statistics)
@@ -28,3 +30,37 @@ type BarFillerFunc func(w io.Writer, reqWidth int, stat decor.Statistics)
 func (f BarFillerFunc) Fill(w io.Writer, reqWidth int, stat decor.Statistics) {
 	f(w, reqWidth, stat)
 }
+
+// BarFillerNop returns BarFiller, which renders nothing. Use it to get
+// a text status line made of decorators only, which still participates
+// in column width sync and bar's lifecycle. Combine with TrimSpace
+// option to get rid of the padding around the empty bar.
+func BarFillerNop() BarFiller {
+	return nopFiller{}
+}
+
+type nopFiller struct{}
+
+func (nopFiller) Fill(io.Writer, int, decor.Statistics) {}
+
+// ShortFill enum, selects how filler of a bar, which has been aborted
+// before completion, is drawn. See BarShortFill option.
+type ShortFill int
+
+// ShortFill kinds.
+const (
+	// ShortFillFreeze keeps bar at fill level it has been aborted at.
+	ShortFillFreeze ShortFill = iota
+	// ShortFillFull snaps bar to full, drawn with refill rune only, so
+	// it's distinct from a regularly completed bar.
+	ShortFillFull
+	// ShortFillMark keeps fill level, drawing unfilled region with
+	// refill rune instead of space rune.
+	ShortFillMark
+)
+
+// shortFiller is implemented by fillers, which have their own look
+// for ShortFill modes. It returns false, if mode can't be honored.
+type shortFiller interface {
+	fillShort(w io.Writer, reqWidth int, stat decor.Statistics, mode ShortFill) bool
+}
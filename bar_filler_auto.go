@@ -0,0 +1,60 @@
+package mpb
+
+import (
+	"io"
+
+	"github.com/vbauerster/mpb/v5/decor"
+)
+
+type autoFiller struct {
+	spinner *spinnerFiller
+	bar     *barFiller
+}
+
+// NewAutoFiller constucts mpb.BarFiller, which renders as a spinner
+// while total is unknown (zero or negative), and as a regular bar,
+// once total has been set. Meant for bars, which learn their size
+// mid-stream, see *Bar.ProxyReaderWithSizeHint. Bar specific options,
+// like BarStyle or BarReverse, are applied to the bar part.
+func NewAutoFiller(spinnerStyle []string, barStyle string, reverse bool) BarFiller {
+	return &autoFiller{
+		spinner: NewSpinnerFiller(spinnerStyle, SpinnerOnLeft).(*spinnerFiller),
+		bar:     NewBarFiller(barStyle, reverse).(*barFiller),
+	}
+}
+
+func (s *autoFiller) Fill(w io.Writer, reqWidth int, stat decor.Statistics) {
+	if stat.Total <= 0 {
+		s.spinner.Fill(w, reqWidth, stat)
+		return
+	}
+	s.bar.Fill(w, reqWidth, stat)
+}
+
+func (s *autoFiller) AdaptTerminal(t Terminal) {
+	s.spinner.AdaptTerminal(t)
+	s.bar.AdaptTerminal(t)
+}
+
+func (s *autoFiller) SetStyle(style string) {
+	s.bar.SetStyle(style)
+}
+
+func (s *autoFiller) SetReverse(reverse bool) {
+	s.bar.SetReverse(reverse)
+}
+
+func (s *autoFiller) SetOverlay(label func(decor.Statistics) string) {
+	s.bar.SetOverlay(label)
+}
+
+func (s *autoFiller) SetGradient(from, to RGB, depth ColorDepth) {
+	s.bar.SetGradient(from, to, depth)
+}
+
+func (s *autoFiller) fillShort(w io.Writer, reqWidth int, stat decor.Statistics, mode ShortFill) bool {
+	if stat.Total <= 0 {
+		return false
+	}
+	return s.bar.fillShort(w, reqWidth, stat, mode)
+}
@@ -40,12 +40,21 @@ const (
 const DefaultBarStyle string = "[=>-]<+"
 
 type barFiller struct {
-	format  [][]byte
-	rwidth  []int
-	tip     []byte
-	refill  int64
-	reverse bool
-	flush   func(io.Writer, *space, [][]byte)
+	format   [][]byte
+	rwidth   []int
+	tip      []byte
+	refill   int64
+	reverse  bool
+	flush    func(io.Writer, *space, [][]byte)
+	ramp     *gradient
+	ellipsis string
+	overlay  func(decor.Statistics) string
+	invert   bool
+}
+
+type gradient struct {
+	from, to RGB
+	depth    ColorDepth
 }
 
 type space struct {
@@ -57,9 +66,10 @@ type space struct {
 // NewBarFiller constucts mpb.BarFiller, to be used with *Progress.Add(...) *Bar method.
 func NewBarFiller(style string, reverse bool) BarFiller {
 	bf := &barFiller{
-		format:  make([][]byte, len(DefaultBarStyle)),
-		rwidth:  make([]int, len(DefaultBarStyle)),
-		reverse: reverse,
+		format:   make([][]byte, len(DefaultBarStyle)),
+		rwidth:   make([]int, len(DefaultBarStyle)),
+		reverse:  reverse,
+		ellipsis: "…",
 	}
 	bf.SetStyle(style)
 	return bf
@@ -94,6 +104,39 @@ func (s *barFiller) SetReverse(reverse bool) {
 	s.reverse = reverse
 }
 
+func (s *barFiller) AdaptTerminal(t Terminal) {
+	if !t.Unicode {
+		s.ellipsis = "..."
+		if !isASCII(s.style()) {
+			s.SetStyle(DefaultBarStyle)
+		}
+	}
+	if s.ramp != nil && s.ramp.depth > t.Colors {
+		s.SetGradient(s.ramp.from, s.ramp.to, t.Colors)
+	}
+	s.invert = t.IsTTY && t.VT
+}
+
+func (s *barFiller) style() string {
+	var b bytes.Buffer
+	for _, f := range s.format {
+		b.Write(f)
+	}
+	return b.String()
+}
+
+func (s *barFiller) SetOverlay(label func(decor.Statistics) string) {
+	s.overlay = label
+}
+
+func (s *barFiller) SetGradient(from, to RGB, depth ColorDepth) {
+	if depth == ColorNone {
+		s.ramp = nil
+		return
+	}
+	s.ramp = &gradient{from, to, depth}
+}
+
 func (s *barFiller) Fill(w io.Writer, reqWidth int, stat decor.Statistics) {
 	width := internal.WidthForBarFiller(reqWidth, stat.AvailableWidth)
 
@@ -145,13 +188,132 @@ func (s *barFiller) Fill(w io.Writer, reqWidth int, stat decor.Statistics) {
 	if cwidth+refill < 0 || space.rwidth > 1 {
 		buf := new(bytes.Buffer)
 		s.flush(buf, space, bb[:index])
-		io.WriteString(w, runewidth.Truncate(buf.String(), width, "…"))
+		io.WriteString(w, runewidth.Truncate(buf.String(), width, s.ellipsis))
 		return
 	}
 
+	if s.ramp != nil {
+		s.ramp.paint(bb[:index], width)
+	}
+
+	if s.overlay != nil && s.narrowCells() {
+		if label := s.overlay(stat); label != "" {
+			s.overlayFlush(w, space, bb, label)
+			return
+		}
+	}
+
 	s.flush(w, space, bb)
 }
 
+// narrowCells reports whether every cell rune is one column wide,
+// which overlay placement relies on.
+func (s *barFiller) narrowCells() bool {
+	for _, i := range [...]int{rFill, rTip, rSpace, rRevTip, rRefill} {
+		if s.rwidth[i] != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// overlayFlush is flush, which puts label centered over the bar's
+// cells. Label's part over filled cells is inverted, if terminal
+// supports it.
+func (s *barFiller) overlayFlush(w io.Writer, space *space, bb [][]byte, label string) {
+	cells := make([][]byte, 0, len(bb)+space.count)
+	var filled []bool
+	spaces := func() {
+		for ; space.count > 0; space.count-- {
+			cells = append(cells, space.space)
+			filled = append(filled, false)
+		}
+	}
+	if s.reverse {
+		spaces()
+		for i := 0; i < len(bb); i++ {
+			cells = append(cells, bb[i])
+			filled = append(filled, true)
+		}
+	} else {
+		for i := len(bb) - 1; i >= 0; i-- {
+			cells = append(cells, bb[i])
+			filled = append(filled, true)
+		}
+		spaces()
+	}
+
+	runes := []rune(runewidth.Truncate(label, len(cells), ""))
+	if runewidth.StringWidth(string(runes)) != len(runes) {
+		// wide runes don't map to cells one to one
+		runes = nil
+	}
+	start := (len(cells) - len(runes)) / 2
+	for i, r := range runes {
+		cell := string(r)
+		if s.invert && filled[start+i] {
+			cell = "\x1b[7m" + cell + "\x1b[27m"
+		}
+		cells[start+i] = []byte(cell)
+	}
+	for _, cell := range cells {
+		w.Write(cell)
+	}
+}
+
+func (s *barFiller) fillShort(w io.Writer, reqWidth int, stat decor.Statistics, mode ShortFill) bool {
+	if s.rwidth[rRefill] == 0 {
+		return false
+	}
+	width := internal.WidthForBarFiller(reqWidth, stat.AvailableWidth)
+
+	if brackets := s.rwidth[rLeft] + s.rwidth[rRight]; width < brackets {
+		return true
+	} else {
+		width -= brackets
+	}
+	w.Write(s.format[rLeft])
+	defer w.Write(s.format[rRight])
+
+	var cwidth int
+	if mode == ShortFillMark {
+		cwidth = int(internal.PercentageRound(stat.Total, stat.Current, width))
+	}
+
+	var bb [][]byte
+	filled := 0
+	for filled < cwidth {
+		bb = append(bb, s.format[rFill])
+		filled += s.rwidth[rFill]
+	}
+	space := &space{
+		space:  s.format[rRefill],
+		rwidth: s.rwidth[rRefill],
+		count:  width - filled,
+	}
+
+	buf := new(bytes.Buffer)
+	s.flush(buf, space, bb)
+	io.WriteString(w, runewidth.Truncate(buf.String(), width, ""))
+	return true
+}
+
+// paint colors filled cells, bb is expected in reverse order, i.e.
+// bb[0] is the farthest cell from the bar's origin.
+func (g *gradient) paint(bb [][]byte, width int) {
+	if width < 2 {
+		width = 2
+	}
+	for i := range bb {
+		pos := len(bb) - 1 - i
+		color := g.from.lerp(g.to, float64(pos)/float64(width-1))
+		cell := make([]byte, 0, len(bb[i])+24)
+		cell = append(cell, color.sgr(g.depth)...)
+		cell = append(cell, bb[i]...)
+		bb[i] = append(cell, sgrReset...)
+	}
+}
+
 func regularFlush(w io.Writer, space *space, bb [][]byte) {
 	for i := len(bb) - 1; i >= 0; i-- {
 		w.Write(bb[i])
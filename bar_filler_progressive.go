@@ -0,0 +1,109 @@
+package mpb
+
+import (
+	"io"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/vbauerster/mpb/v5/decor"
+	"github.com/vbauerster/mpb/v5/internal"
+)
+
+// BrailleBarStyle is eight-dot braille progressive style. Each cell
+// of the bar is filled dot by dot, which gives eight steps of
+// resolution per cell.
+//
+//	'1st rune' stands for empty cell
+//
+//	'last rune' stands for full cell
+//
+//	'runes in between' stand for partially filled cell, in order of
+//	fill level
+//
+const BrailleBarStyle string = "⠀⡀⡄⡆⡇⣇⣧⣷⣿"
+
+const asciiProgressiveStyle = " .:#"
+
+type progressiveFiller struct {
+	frames  [][]byte
+	rwidth  int
+	reverse bool
+}
+
+// NewProgressiveBarFiller constucts mpb.BarFiller, which fills each
+// cell progressively, to be used with *Progress.Add(...) *Bar method.
+// If style is empty, BrailleBarStyle is used.
+func NewProgressiveBarFiller(style string, reverse bool) BarFiller {
+	pf := &progressiveFiller{reverse: reverse}
+	pf.SetStyle(style)
+	return pf
+}
+
+func (s *progressiveFiller) SetStyle(style string) {
+	if !utf8.ValidString(style) {
+		panic("invalid bar style")
+	}
+	if utf8.RuneCountInString(style) < 2 {
+		style = BrailleBarStyle
+	}
+	frames := make([][]byte, 0, utf8.RuneCountInString(style))
+	rwidth := 1
+	for _, r := range style {
+		if w := runewidth.RuneWidth(r); w > rwidth {
+			rwidth = w
+		}
+		frames = append(frames, []byte(string(r)))
+	}
+	s.frames = frames
+	s.rwidth = rwidth
+}
+
+func (s *progressiveFiller) AdaptTerminal(t Terminal) {
+	if t.Unicode {
+		return
+	}
+	for _, f := range s.frames {
+		if !isASCII(string(f)) {
+			s.SetStyle(asciiProgressiveStyle)
+			return
+		}
+	}
+}
+
+func (s *progressiveFiller) SetReverse(reverse bool) {
+	s.reverse = reverse
+}
+
+func (s *progressiveFiller) Fill(w io.Writer, reqWidth int, stat decor.Statistics) {
+	width := internal.WidthForBarFiller(reqWidth, stat.AvailableWidth) / s.rwidth
+	if width <= 0 {
+		return
+	}
+
+	steps := len(s.frames) - 1
+	filled := int(internal.PercentageRound(stat.Total, stat.Current, width*steps))
+
+	cells := make([][]byte, width)
+	for i := range cells {
+		switch {
+		case filled >= steps:
+			cells[i] = s.frames[steps]
+			filled -= steps
+		case filled > 0:
+			cells[i] = s.frames[filled]
+			filled = 0
+		default:
+			cells[i] = s.frames[0]
+		}
+	}
+
+	if s.reverse {
+		for i := len(cells) - 1; i >= 0; i-- {
+			w.Write(cells[i])
+		}
+		return
+	}
+	for _, cell := range cells {
+		w.Write(cell)
+	}
+}
@@ -22,6 +22,11 @@ const (
 // DefaultSpinnerStyle is a slice of strings, which makes a spinner.
 var DefaultSpinnerStyle = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 
+var asciiSpinnerStyle = []string{"-", "\\", "|", "/"}
+
+// BrailleSpinnerStyle is eight-dot braille spinner style.
+var BrailleSpinnerStyle = []string{"⣾", "⣽", "⣻", "⢿", "⡿", "⣟", "⣯", "⣷"}
+
 type spinnerFiller struct {
 	frames    []string
 	count     uint
@@ -40,6 +45,13 @@ func NewSpinnerFiller(style []string, alignment SpinnerAlignment) BarFiller {
 	return filler
 }
 
+func (s *spinnerFiller) AdaptTerminal(t Terminal) {
+	if t.Unicode || isASCII(strings.Join(s.frames, "")) {
+		return
+	}
+	s.frames = asciiSpinnerStyle
+}
+
 func (s *spinnerFiller) Fill(w io.Writer, reqWidth int, stat decor.Statistics) {
 	width := internal.WidthForBarFiller(reqWidth, stat.AvailableWidth)
 
@@ -2,9 +2,12 @@ package mpb
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"time"
 
 	"github.com/vbauerster/mpb/v5/decor"
+	"github.com/vbauerster/mpb/v5/internal"
 )
 
 // BarOption is a function option which changes the default behavior of a bar.
@@ -36,6 +39,34 @@ func PrependDecorators(decorators ...decor.Decorator) BarOption {
 	}
 }
 
+// BarDecoratorSeparator sets separator, which is rendered in between
+// adjacent decorators of the same side, overriding one set with
+// WithDecoratorSeparator container option. Bars, which share width
+// synced columns, should use the same separator.
+func BarDecoratorSeparator(sep string) BarOption {
+	return func(s *bState) {
+		s.separator = sep
+	}
+}
+
+// BarLayout declares bar's decorators with a template, like
+// "{name} {bar} {counters} | {speed} | {eta}". Placeholders are looked
+// up in decorators map by name, the special {bar} placeholder stands
+// for the bar itself, placeholders before it go to prepend side and
+// ones after it go to append side. Text in between placeholders is
+// rendered as is, keep in mind that bar is padded with a space on
+// each side, unless TrimSpace option is set. Decorator separator, see
+// WithDecoratorSeparator, is rendered only in between adjacent
+// placeholders, never next to text. Decorators are added after ones
+// set by other options. Panics on unknown or unterminated placeholder.
+func BarLayout(tmpl string, decorators map[string]decor.Decorator) BarOption {
+	pDecorators, aDecorators := compileLayout(tmpl, decorators)
+	return func(s *bState) {
+		s.addDecorators(&s.pDecorators, pDecorators...)
+		s.addDecorators(&s.aDecorators, aDecorators...)
+	}
+}
+
 // BarID sets bar id.
 func BarID(id int) BarOption {
 	return func(s *bState) {
@@ -43,6 +74,43 @@ func BarID(id int) BarOption {
 	}
 }
 
+// BarName sets bar name. Name isn't rendered by itself, it's exposed
+// via decor.Statistics.Name, for example to *Progress.Snapshot()
+// consumers.
+func BarName(name string) BarOption {
+	return func(s *bState) {
+		s.name = name
+	}
+}
+
+// BarTickFunc sets fn, which is called by the render loop each frame
+// to pull bar's current value from an external source, like an atomic
+// counter or a database row, in addition to push based Incr calls.
+// Complete event is triggered as usual, once pulled value reaches
+// total. Panic in fn is recovered the same way as panic in decorator.
+func BarTickFunc(fn func(decor.Statistics) int64) BarOption {
+	if fn == nil {
+		return nil
+	}
+	return func(s *bState) {
+		s.tickFunc = fn
+	}
+}
+
+// BarFloatScale enables fractional progress, see *Bar.SetTotalFloat,
+// *Bar.SetCurrentFloat and *Bar.IncrFloat64. Float values are stored
+// multiplied by scale, for example scale 100 keeps two decimal digits.
+// Use decor.CountersFloat or decor.Statistics.CurrentFloat to display
+// unscaled values.
+func BarFloatScale(scale float64) BarOption {
+	if scale <= 0 {
+		return nil
+	}
+	return func(s *bState) {
+		s.scale = scale
+	}
+}
+
 // BarWidth sets bar width independent of the container.
 func BarWidth(width int) BarOption {
 	return func(s *bState) {
@@ -89,12 +157,65 @@ func BarFillerOnComplete(message string) BarOption {
 }
 
 // BarFillerMiddleware provides a way to augment default BarFiller.
+// Middlewares compose, the one passed last wraps the others.
 func BarFillerMiddleware(middle func(BarFiller) BarFiller) BarOption {
 	return func(s *bState) {
+		if prev := s.middleware; prev != nil {
+			s.middleware = func(base BarFiller) BarFiller {
+				return middle(prev(base))
+			}
+			return
+		}
 		s.middleware = middle
 	}
 }
 
+// BarSparkline renders a sparkline of recent speed history, sampled
+// once per interval, either next to the bar or on a line below it,
+// after lines of extenders set by preceding options. History is fed by
+// Incr... family methods. It's handy to diagnose bursty transfers.
+//
+//	`size` number of samples, i.e. sparkline width
+//
+//	`interval` sampling interval, 1s if zero
+//
+//	`below` render sparkline below the bar, instead of next to it
+//
+func BarSparkline(size int, interval time.Duration, below bool) BarOption {
+	if size <= 0 {
+		return nil
+	}
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return func(s *bState) {
+		sl := newSparkline(size, interval, s.term.Unicode)
+		s.incrListeners = append(s.incrListeners, sl.add)
+		if below {
+			s.extender = chainExtFunc(s.extender, makeExtFunc(BarFillerFunc(func(w io.Writer, reqWidth int, st decor.Statistics) {
+				sl.Fill(w, reqWidth, st)
+				io.WriteString(w, "\n")
+			})))
+			return
+		}
+		BarFillerMiddleware(func(base BarFiller) BarFiller {
+			return BarFillerFunc(func(w io.Writer, reqWidth int, st decor.Statistics) {
+				if st.AvailableWidth <= size+1 {
+					base.Fill(w, reqWidth, st)
+					return
+				}
+				st.AvailableWidth -= size + 1
+				if reqWidth > size+1 {
+					reqWidth -= size + 1
+				}
+				base.Fill(w, reqWidth, st)
+				io.WriteString(w, " ")
+				sl.Fill(w, reqWidth, st)
+			})
+		})(s)
+	}
+}
+
 // BarPriority sets bar's priority. Zero is highest priority, i.e. bar
 // will be on top. If `BarReplaceOnComplete` option is supplied, this
 // option is ignored.
@@ -115,6 +236,16 @@ func BarExtender(filler BarFiller) BarOption {
 	}
 }
 
+// chainExtFunc makes extender, which renders lines of prev first and
+// then lines of next.
+func chainExtFunc(prev, next extFunc) extFunc {
+	return func(r io.Reader, reqWidth int, st decor.Statistics) (io.Reader, int) {
+		r, n := prev(r, reqWidth, st)
+		r, m := next(r, reqWidth, st)
+		return r, n + m
+	}
+}
+
 func makeExtFunc(filler BarFiller) extFunc {
 	buf := new(bytes.Buffer)
 	return func(r io.Reader, reqWidth int, st decor.Statistics) (io.Reader, int) {
@@ -123,6 +254,20 @@ func makeExtFunc(filler BarFiller) extFunc {
 	}
 }
 
+// BarCompactLayout enables compact layout, meant for narrow terminals.
+// Bar shrinks first, and once it's narrower than minBarWidth,
+// decorators marked with decor.DropPriority are dropped one by one,
+// until bar fits. Dropped decorators are still called, so width sync
+// keeps working.
+func BarCompactLayout(minBarWidth int) BarOption {
+	if minBarWidth <= 0 {
+		minBarWidth = 10
+	}
+	return func(s *bState) {
+		s.compactMin = minBarWidth
+	}
+}
+
 // TrimSpace trims bar's edge spaces.
 func TrimSpace() BarOption {
 	return func(s *bState) {
@@ -147,6 +292,66 @@ func BarStyle(style string) BarOption {
 	}
 }
 
+// BarOverlay prints label centered inside the bar itself, instead of
+// a separate decorator column. Label's part over the filled portion
+// is inverted, if output is a VT capable terminal. If label is nil,
+// percentage is printed. Label is skipped, if bar's style or label
+// has wide runes. Effective when Filler type is bar.
+func BarOverlay(label func(decor.Statistics) string) BarOption {
+	if label == nil {
+		label = func(st decor.Statistics) string {
+			return fmt.Sprintf("%d%%", int(internal.Percentage(st.Total, st.Current, 100)))
+		}
+	}
+	type overlaySetter interface {
+		SetOverlay(func(decor.Statistics) string)
+	}
+	return func(s *bState) {
+		if t, ok := s.filler.(overlaySetter); ok {
+			t.SetOverlay(label)
+		}
+	}
+}
+
+// BarGradient colors filled cells of the bar with a gradient ramp,
+// starting from `from` color at bar's origin and ending with `to`
+// color at bar's end. Colors are downgraded to 256-color palette or
+// dropped altogether, according to detected Terminal.
+// Effective when Filler type is bar.
+func BarGradient(from, to RGB) BarOption {
+	type gradientSetter interface {
+		SetGradient(RGB, RGB, ColorDepth)
+	}
+	return func(s *bState) {
+		if t, ok := s.filler.(gradientSetter); ok {
+			t.SetGradient(from, to, s.term.Colors)
+		}
+	}
+}
+
+// BarShortFill selects how filler is drawn, once bar has been aborted
+// before completion, see ShortFill kinds. Default is ShortFillFreeze,
+// which may look like a hang. Custom fillers, which don't implement
+// their own look, get ShortFillMark as ShortFillFreeze and
+// ShortFillFull as a filler at full. Aborted state is exposed via
+// decor.Statistics.Aborted regardless of mode.
+func BarShortFill(mode ShortFill) BarOption {
+	return func(s *bState) {
+		s.shortFill = mode
+	}
+}
+
+// BarPending creates bar in pending state, so worker pool may create
+// bars for all queued tasks upfront. Pending bar is rendered dimmed,
+// if terminal supports it, and its time accounting is on hold, until
+// *Bar.Start is called or bar gets its first update. Pending state is
+// exposed via decor.Statistics.Pending, see decor.Queued.
+func BarPending() BarOption {
+	return func(s *bState) {
+		s.pending = true
+	}
+}
+
 // BarNoPop disables bar pop out of container. Effective when
 // PopCompletedMode of container is enabled.
 func BarNoPop() BarOption {
@@ -155,6 +360,15 @@ func BarNoPop() BarOption {
 	}
 }
 
+// BarNoClamp disables clamping of bar's current value at total on
+// complete event, so current may exceed total. Either way amount past
+// total is exposed via decor.Statistics.Overflow.
+func BarNoClamp() BarOption {
+	return func(s *bState) {
+		s.noClamp = true
+	}
+}
+
 // BarReverse reverse mode, bar will progress from right to left.
 func BarReverse() BarOption {
 	type revSetter interface {
@@ -3,8 +3,10 @@ package mpb_test
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -255,3 +257,495 @@ func panicDecorator(panicMsg string, cond func(decor.Statistics) bool) decor.Dec
 		return ""
 	})
 }
+
+func TestBarOnProgress(t *testing.T) {
+	p := New(WithWidth(80), WithOutput(ioutil.Discard))
+	total := 100
+	bar := p.AddBar(int64(total))
+
+	var half, done uint32
+	bar.OnProgress(0.5, func() { atomic.AddUint32(&half, 1) })
+	bar.OnProgress(1, func() { atomic.AddUint32(&done, 1) })
+
+	for i := 0; i < total; i++ {
+		bar.Increment()
+		if i == total/2-2 && atomic.LoadUint32(&half) != 0 {
+			t.Error("threshold 0.5 fired too early")
+		}
+	}
+
+	p.Wait()
+	time.Sleep(10 * time.Millisecond)
+
+	if got := atomic.LoadUint32(&half); got != 1 {
+		t.Errorf("threshold 0.5 fired %d times, expected once", got)
+	}
+	if got := atomic.LoadUint32(&done); got != 1 {
+		t.Errorf("threshold 1 fired %d times, expected once", got)
+	}
+}
+
+func TestBarOverflow(t *testing.T) {
+	for _, noClamp := range []bool{false, true} {
+		p := New(WithOutput(ioutil.Discard))
+		bar := p.AddBar(10, BarOptOn(BarNoClamp(), func() bool { return noClamp }))
+
+		bar.IncrBy(15)
+		bar.IncrBy(5)
+
+		p.Wait()
+
+		st := p.Snapshot()[0]
+		if st.Overflow != 10 {
+			t.Errorf("noClamp=%t: want overflow: 10, got: %d\n", noClamp, st.Overflow)
+		}
+		wantCurrent := int64(10)
+		if noClamp {
+			wantCurrent = 20
+		}
+		if st.Current != wantCurrent {
+			t.Errorf("noClamp=%t: want current: %d, got: %d\n", noClamp, wantCurrent, st.Current)
+		}
+	}
+}
+
+func TestBarOverflowSetCurrent(t *testing.T) {
+	p := New(WithOutput(ioutil.Discard))
+	bar := p.AddBar(100)
+
+	bar.SetCurrent(120)
+	bar.SetCurrent(130)
+
+	p.Wait()
+
+	st := p.Snapshot()[0]
+	if st.Overflow != 30 {
+		t.Errorf("want overflow: 30, got: %d\n", st.Overflow)
+	}
+	if st.Current != 100 {
+		t.Errorf("want current: 100, got: %d\n", st.Current)
+	}
+}
+
+func TestBarPauseResume(t *testing.T) {
+	p := New(WithOutput(ioutil.Discard))
+	bar := p.AddBar(10)
+
+	bar.Pause()
+	time.Sleep(100 * time.Millisecond)
+	bar.Resume()
+	bar.SetTotal(0, true)
+
+	p.Wait()
+
+	st := p.Snapshot()[0]
+	if st.Paused {
+		t.Error("bar is expected to be resumed")
+	}
+	if paused := st.Elapsed - st.ActiveElapsed; paused < 100*time.Millisecond {
+		t.Errorf("expected at least 100ms of pause, got: %s", paused)
+	}
+}
+
+func TestBarReset(t *testing.T) {
+	p := New(WithOutput(ioutil.Discard))
+	bar := p.AddBar(100)
+
+	var fired uint32
+	bar.OnProgress(0.5, func() { atomic.AddUint32(&fired, 1) })
+
+	bar.IncrBy(60)
+	bar.Reset(50)
+	if got := bar.Current(); got != 0 {
+		t.Errorf("expected current 0 after reset, got: %d", got)
+	}
+	bar.IncrBy(50)
+
+	p.Wait()
+	time.Sleep(10 * time.Millisecond)
+
+	st := p.Snapshot()[0]
+	if st.Attempt != 2 {
+		t.Errorf("want attempt: 2, got: %d", st.Attempt)
+	}
+	if st.Total != 50 || st.Current != 50 {
+		t.Errorf("want: 50/50, got: %d/%d", st.Current, st.Total)
+	}
+	if got := atomic.LoadUint32(&fired); got != 2 {
+		t.Errorf("expected hook to fire on each attempt, fired: %d", got)
+	}
+}
+
+func TestBarResetAfterComplete(t *testing.T) {
+	p := New(WithOutput(ioutil.Discard))
+	bar := p.AddBar(10)
+
+	bar.IncrBy(10)
+	bar.Reset(100)
+
+	p.Wait()
+
+	st := p.Snapshot()[0]
+	if !st.Completed {
+		t.Error("expected bar to stay completed")
+	}
+	if st.Attempt != 1 {
+		t.Errorf("want attempt: 1, got: %d", st.Attempt)
+	}
+	if st.Total != 10 || st.Current != 10 {
+		t.Errorf("want: 10/10, got: %d/%d", st.Current, st.Total)
+	}
+}
+
+func TestBarFloatScale(t *testing.T) {
+	p := New(WithOutput(ioutil.Discard))
+	bar := p.AddBar(0, BarFloatScale(1000))
+	bar.SetTotalFloat(1.5, false)
+
+	// 0.0001 doesn't fit into scale, but adds up
+	for i := 0; i < 5000; i++ {
+		bar.IncrFloat64(0.0001)
+	}
+	if got := bar.Current(); got != 500 {
+		t.Errorf("want current: 500, got: %d", got)
+	}
+
+	bar.SetCurrentFloat(1.5)
+	bar.SetTotal(0, true)
+
+	p.Wait()
+
+	st := p.Snapshot()[0]
+	if got := st.CurrentFloat(); got != 1.5 {
+		t.Errorf("want current float: 1.5, got: %f", got)
+	}
+	if got := decor.CountersFloat("").Decor(st); got != "1.50 / 1.50" {
+		t.Errorf("want: %q, got: %q", "1.50 / 1.50", got)
+	}
+}
+
+func TestBarWait(t *testing.T) {
+	p := New(WithOutput(ioutil.Discard), WithRefreshRate(10*time.Millisecond))
+
+	upstream := p.AddBar(10)
+	aborted := p.AddBar(10)
+	downstream := p.AddBar(2)
+
+	go func() {
+		for i := 0; i < 10; i++ {
+			time.Sleep(5 * time.Millisecond)
+			upstream.Increment()
+		}
+	}()
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		aborted.Abort(false)
+	}()
+
+	upstream.Wait()
+	if !upstream.Completed() || upstream.Current() != 10 {
+		t.Errorf("upstream is not complete after Wait, current: %d", upstream.Current())
+	}
+	downstream.Increment()
+
+	aborted.Wait()
+	if aborted.Current() != 0 {
+		t.Errorf("unexpected current of aborted bar: %d", aborted.Current())
+	}
+	downstream.Increment()
+
+	p.Wait()
+}
+
+func TestBarTickFunc(t *testing.T) {
+	p := New(WithOutput(ioutil.Discard), WithRefreshRate(10*time.Millisecond))
+
+	var counter int64
+	var ticks int32
+	bar := p.AddBar(100, BarTickFunc(func(st decor.Statistics) int64 {
+		atomic.AddInt32(&ticks, 1)
+		return atomic.LoadInt64(&counter)
+	}))
+
+	for i := 0; i < 10; i++ {
+		atomic.AddInt64(&counter, 10)
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		p.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("bar has not been completed by pulled value")
+	}
+
+	if got := bar.Current(); got != 100 {
+		t.Errorf("want current: 100, got: %d", got)
+	}
+	if atomic.LoadInt32(&ticks) == 0 {
+		t.Error("tick func has not been called")
+	}
+}
+
+func TestBarShortFill(t *testing.T) {
+	tests := map[ShortFill]string{
+		ShortFillFreeze: "[====>----]!",
+		ShortFillFull:   "[+++++++++]!",
+		ShortFillMark:   "[=====++++]!",
+	}
+
+	for mode, want := range tests {
+		var buf bytes.Buffer
+		p := New(WithOutput(&buf), WithWidth(12))
+		bar := p.AddBar(100, BarShortFill(mode), TrimSpace(),
+			AppendDecorators(decor.Any(func(st decor.Statistics) string {
+				if st.Aborted {
+					return "!"
+				}
+				return ""
+			})),
+		)
+		bar.SetCurrent(50)
+		bar.Abort(false)
+		p.Wait()
+
+		out := buf.String()
+		if i := strings.LastIndex(out, "\x1b[J"); i >= 0 {
+			out = out[i+3:]
+		}
+		if got := strings.TrimSpace(out); got != want {
+			t.Errorf("mode %d: want %q, got %q", mode, want, got)
+		}
+	}
+}
+
+func TestFrameSnapshotConsistency(t *testing.T) {
+	p := New(WithOutput(ioutil.Discard), WithRefreshRate(time.Millisecond))
+
+	total := 20000
+	var frameCurrent int64
+	var mismatch int32
+	check := func(st decor.Statistics) {
+		if st.Current != atomic.LoadInt64(&frameCurrent) {
+			atomic.StoreInt32(&mismatch, 1)
+		}
+	}
+
+	bar := p.Add(int64(total),
+		BarFillerFunc(func(w io.Writer, _ int, st decor.Statistics) {
+			check(st)
+		}),
+		PrependDecorators(
+			decor.Any(func(st decor.Statistics) string {
+				atomic.StoreInt64(&frameCurrent, st.Current)
+				return ""
+			}),
+			decor.Any(func(st decor.Statistics) string {
+				check(st)
+				return ""
+			}),
+		),
+		AppendDecorators(
+			decor.Any(func(st decor.Statistics) string {
+				check(st)
+				return ""
+			}),
+		),
+	)
+
+	var wg sync.WaitGroup
+	workers := 50
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < total/workers; j++ {
+				bar.Increment()
+			}
+		}()
+	}
+	wg.Wait()
+	p.Wait()
+
+	if atomic.LoadInt32(&mismatch) != 0 {
+		t.Error("decorators observed different values within one frame")
+	}
+}
+
+func TestBarPending(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(WithOutput(&buf), WithWidth(80), WithRefreshRate(10*time.Millisecond))
+
+	bar := p.AddBar(10, BarPending(), TrimSpace(),
+		PrependDecorators(decor.Queued("queued", decor.WCSyncWidth)),
+	)
+
+	time.Sleep(50 * time.Millisecond)
+	st := p.Snapshot()[0]
+	if !st.Pending {
+		t.Error("expected pending bar")
+	}
+	if st.Elapsed != 0 {
+		t.Errorf("pending bar expected to have zero elapsed, got: %s", st.Elapsed)
+	}
+
+	bar.Start()
+	if p.Snapshot()[0].Pending {
+		t.Error("expected started bar")
+	}
+	bar.IncrBy(10)
+	p.Wait()
+
+	out := buf.String()
+	if !strings.Contains(out, "queued") {
+		t.Error("expected queued message, while bar was pending")
+	}
+	last := out[strings.LastIndex(out, "\x1b[J")+3:]
+	if strings.Contains(last, "queued") {
+		t.Errorf("unexpected queued message in final frame: %q", last)
+	}
+}
+
+func TestBarLayout(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(WithOutput(&buf), WithWidth(30))
+
+	bar := p.Add(10, nil, TrimSpace(),
+		BarLayout("{name}: {bar}{counters} | {state}", map[string]decor.Decorator{
+			"name":     decor.Name("job"),
+			"counters": decor.CountersNoUnit("%d/%d"),
+			"state":    decor.OnComplete(decor.Name("running"), "done"),
+		}),
+	)
+	bar.IncrBy(10)
+	p.Wait()
+
+	want := "job: 10/10 | done"
+	if got := string(getLastLine(buf.Bytes())); !strings.HasSuffix(got, want) {
+		t.Errorf("want: %q, got: %q", want, got)
+	}
+
+	for _, tmpl := range []string{"{name", "{unknown}"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic on %q layout", tmpl)
+				}
+			}()
+			BarLayout(tmpl, map[string]decor.Decorator{"name": decor.Name("x")})
+		}()
+	}
+}
+
+func TestBarLayoutSeparator(t *testing.T) {
+	for _, compact := range []bool{false, true} {
+		var buf bytes.Buffer
+		p := New(WithOutput(&buf), WithWidth(40), WithDecoratorSeparator(" | "))
+
+		options := []BarOption{TrimSpace(),
+			BarLayout("[{name}{id}]: {bar}{counters} ({state})", map[string]decor.Decorator{
+				"name":     decor.Name("job"),
+				"id":       decor.Name("#1"),
+				"counters": decor.CountersNoUnit("%d/%d"),
+				"state":    decor.OnComplete(decor.Name("running"), "done"),
+			}),
+		}
+		if compact {
+			options = append(options, BarCompactLayout(5))
+		}
+		bar := p.Add(10, nil, options...)
+		bar.IncrBy(10)
+		p.Wait()
+
+		want := "[job | #1]: 10/10 (done)"
+		if got := string(getLastLine(buf.Bytes())); !strings.HasSuffix(got, want) {
+			t.Errorf("compact %t: want: %q, got: %q", compact, want, got)
+		}
+	}
+}
+
+func TestBarSparklineChainsExtender(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(WithOutput(&buf), WithWidth(40))
+
+	bar := p.AddBar(10,
+		BarExtender(BarFillerFunc(func(w io.Writer, _ int, _ decor.Statistics) {
+			io.WriteString(w, "extended\n")
+		})),
+		BarSparkline(4, time.Millisecond, true),
+	)
+	for i := 0; i < 10; i++ {
+		time.Sleep(time.Millisecond)
+		bar.Increment()
+	}
+	p.Wait()
+
+	out := buf.String()
+	lines := strings.Split(strings.TrimSuffix(out[strings.LastIndex(out, "\x1b[J")+3:], "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected bar, extender and sparkline lines, got: %q", lines)
+	}
+	if lines[1] != "extended" {
+		t.Errorf("expected extender line, got: %q", lines[1])
+	}
+	if got := utf8.RuneCountInString(lines[2]); got != 4 || strings.TrimSpace(lines[2]) == "" {
+		t.Errorf("expected sparkline of 4 samples, got: %q", lines[2])
+	}
+}
+
+func TestBarTextMetrics(t *testing.T) {
+	metrics := decor.Metrics{
+		TabStop:   2,
+		RuneWidth: map[rune]int{'\u2600': 2},
+	}
+	family := "\U0001F468\u200d\U0001F469\u200d\U0001F467"
+	tests := []struct {
+		width int
+		name  string
+		want  string
+	}{
+		{20, "a\tb\u2600\ufe0f", "a b\u2600\ufe0f |"},
+		{6, family + "abcdefgh", family + "abc…"},
+	}
+	for _, tc := range tests {
+		var buf bytes.Buffer
+		p := New(WithOutput(&buf), WithWidth(tc.width), WithTextMetrics(metrics))
+		bar := p.Add(1, nil, TrimSpace(),
+			PrependDecorators(decor.Name(tc.name), decor.Name(" |")),
+		)
+		bar.Increment()
+		p.Wait()
+
+		if got := string(getLastLine(buf.Bytes())); !strings.HasSuffix(got, tc.want) {
+			t.Errorf("width %d: want: %q, got: %q", tc.width, tc.want, got)
+		}
+	}
+}
+
+func TestBarIncrError(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(WithOutput(&buf), WithWidth(80))
+
+	bar := p.AddBar(10, BarFillerClearOnComplete(), TrimSpace(),
+		AppendDecorators(decor.Errors("")),
+	)
+	for i := 0; i < 10; i++ {
+		if i%3 == 0 {
+			bar.IncrError(1)
+		}
+		bar.Increment()
+	}
+	p.Wait()
+
+	want := "errs: 4"
+	if got := string(getLastLine(buf.Bytes())); !strings.HasSuffix(got, want) {
+		t.Errorf("want: %q, got: %q", want, got)
+	}
+	if got := p.Snapshot()[0].Errors; got != 4 {
+		t.Errorf("want 4 errors in statistics, got: %d", got)
+	}
+}
@@ -0,0 +1,57 @@
+package mpb
+
+import (
+	"strconv"
+
+	"github.com/vbauerster/mpb/v5/internal/term"
+)
+
+// ColorDepth enum.
+type ColorDepth int
+
+// ColorDepth kinds.
+const (
+	ColorNone ColorDepth = term.ColorNone
+	Color256  ColorDepth = term.Color256
+	ColorTrue ColorDepth = term.ColorTrue
+)
+
+const (
+	sgrReset  = "\x1b[0m"
+	sgrDim    = "\x1b[2m"
+	sgrNormal = "\x1b[22m"
+)
+
+// RGB represents 24-bit color.
+type RGB struct {
+	R, G, B uint8
+}
+
+// sgr returns foreground SGR escape sequence of the color, downgraded
+// to provided depth. Empty string is returned for ColorNone.
+func (c RGB) sgr(depth ColorDepth) string {
+	switch depth {
+	case ColorTrue:
+		return "\x1b[38;2;" +
+			strconv.Itoa(int(c.R)) + ";" +
+			strconv.Itoa(int(c.G)) + ";" +
+			strconv.Itoa(int(c.B)) + "m"
+	case Color256:
+		cube := func(v uint8) int {
+			return (int(v)*5 + 127) / 255
+		}
+		n := 16 + 36*cube(c.R) + 6*cube(c.G) + cube(c.B)
+		return "\x1b[38;5;" + strconv.Itoa(n) + "m"
+	default:
+		return ""
+	}
+}
+
+// lerp interpolates linearly between c and to, t is expected to be
+// in [0, 1] range.
+func (c RGB) lerp(to RGB, t float64) RGB {
+	mix := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+	}
+	return RGB{mix(c.R, to.R), mix(c.G, to.G), mix(c.B, to.B)}
+}
@@ -1,10 +1,13 @@
 package mpb
 
 import (
+	"encoding/csv"
 	"io"
 	"io/ioutil"
 	"sync"
 	"time"
+
+	"github.com/vbauerster/mpb/v5/decor"
 )
 
 // ContainerOption is a function option which changes the default
@@ -36,6 +39,107 @@ func WithRefreshRate(d time.Duration) ContainerOption {
 	}
 }
 
+// WithRefreshOnIncrement makes container refresh on bar updates,
+// instead of a fixed tick. Refreshes are debounced, so refresh rate,
+// see WithRefreshRate, becomes a max refresh rate. Meanwhile a slow
+// tick of keepAlive interval keeps animations, like spinners and
+// elapsed time, going. Has no effect together with WithManualRefresh.
+func WithRefreshOnIncrement(keepAlive time.Duration) ContainerOption {
+	if keepAlive <= 0 {
+		keepAlive = time.Second
+	}
+	return func(s *pState) {
+		s.keepAlive = keepAlive
+	}
+}
+
+// WithIdleRefresh sets render budget for idle bars. A bar, which
+// state hasn't changed since its last render, is re-rendered only on
+// every n-th refresh, its last frame is reused in between. Useful with
+// hundreds of bars, where most of them are idle at any given moment.
+// Synced column widths are kept consistent: if a column grows, idle
+// bars are re-rendered on the next refresh. Values less than 2
+// disable render budget, which is the default.
+func WithIdleRefresh(n int) ContainerOption {
+	return func(s *pState) {
+		s.idleRefresh = n
+	}
+}
+
+// WithSyncGroup makes container share widths of synced decorator
+// columns with other containers of the same group, see SyncGroup.
+func WithSyncGroup(group *SyncGroup) ContainerOption {
+	return func(s *pState) {
+		s.syncGroup = group
+	}
+}
+
+// WithDecoratorSeparator sets separator, which is rendered in between
+// adjacent decorators of the same side, i.e. prepend or append, of
+// every bar. Bars may override it with BarDecoratorSeparator option.
+func WithDecoratorSeparator(sep string) ContainerOption {
+	return func(s *pState) {
+		s.separator = sep
+	}
+}
+
+// WithTextMetrics sets Metrics, display width of decorator text is
+// measured with, like tab stop or rune width overrides for exotic
+// glyphs rendered differently by user's terminal. It applies to every
+// decorator, which doesn't set its own WC.M, and to composed line.
+func WithTextMetrics(m decor.Metrics) ContainerOption {
+	return func(s *pState) {
+		s.metrics = m
+	}
+}
+
+// WithHeaderRow renders a header row above all bars, so multi bar
+// output reads like a table. Titles are matched with width synced
+// decorators, i.e. ones with DSyncWidth bit set, in order of their
+// appearance, and take part in width sync as well. Titles are
+// realigned whenever column widths change. Decorators without
+// DSyncWidth bit have no header counterpart.
+//
+//	`prependTitles` titles of prepend decorator columns
+//
+//	`appendTitles` titles of append decorator columns
+//
+func WithHeaderRow(prependTitles, appendTitles []string) ContainerOption {
+	return func(s *pState) {
+		s.headerRow = [2][]string{prependTitles, appendTitles}
+	}
+}
+
+// WithTrailingNewlines writes n blank lines after bars have been
+// rendered for the last time, i.e. right before *Progress.Wait()
+// returns. Regardless of this option, cursor is always left at the
+// start of a line below bars.
+func WithTrailingNewlines(n int) ContainerOption {
+	return func(s *pState) {
+		s.trailingNewlines = n
+	}
+}
+
+// WithCursorRestore saves cursor position before bars are rendered for
+// the first time, and restores it right before *Progress.Wait()
+// returns. Rendered bars are not cleared. Effective for terminal
+// output only.
+func WithCursorRestore() ContainerOption {
+	return func(s *pState) {
+		s.cursorRestore = true
+	}
+}
+
+// WithASCIIOnly forces all built-in fillers, spinners and truncation
+// ellipses to pure ASCII equivalents, regardless of terminal
+// detection. Useful for legacy terminals and log processors, which
+// choke on unicode.
+func WithASCIIOnly() ContainerOption {
+	return func(s *pState) {
+		s.asciiOnly = true
+	}
+}
+
 // WithManualRefresh disables internal auto refresh time.Ticker.
 // Refresh will occur upon receive value from provided ch.
 func WithManualRefresh(ch <-chan time.Time) ContainerOption {
@@ -54,6 +158,20 @@ func WithRenderDelay(ch <-chan struct{}) ContainerOption {
 	}
 }
 
+// WithRenderFrameHook lets fn post-process each frame before it's
+// written to the output. Frame is passed as lines without trailing
+// newline, fn may modify, drop or add lines, for example to inject a
+// header, to strip colors or to tee frames into a file. Lines passed
+// to fn are only valid during the call.
+func WithRenderFrameHook(fn func(lines [][]byte) [][]byte) ContainerOption {
+	if fn == nil {
+		return nil
+	}
+	return func(s *pState) {
+		s.frameHook = fn
+	}
+}
+
 // WithShutdownNotifier provided chanel will be closed, after all bars
 // have been rendered.
 func WithShutdownNotifier(ch chan struct{}) ContainerOption {
@@ -86,6 +204,121 @@ func WithDebugOutput(w io.Writer) ContainerOption {
 	}
 }
 
+// WithLogOutput writes plain text snapshots of all bars, without any
+// escape sequences, to w at most once per interval, so progress
+// history can be read from log files after the fact. The last frame is
+// always written.
+func WithLogOutput(w io.Writer, interval time.Duration) ContainerOption {
+	if w == nil {
+		return nil
+	}
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	return func(s *pState) {
+		s.frameLog = &frameLogger{w: w, interval: interval}
+	}
+}
+
+// WithHistoryRecorder appends timestamped rows of each bar's current,
+// total and speed, in units per second, to w at most once per interval,
+// so performance of batch transfers can be analyzed later in a
+// spreadsheet. The first row is a header. Set w.Comma to '\t' to get
+// TSV output.
+func WithHistoryRecorder(w *csv.Writer, interval time.Duration) ContainerOption {
+	if w == nil {
+		return nil
+	}
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return func(s *pState) {
+		s.history = &historyRecorder{w: w, interval: interval}
+	}
+}
+
+// WithAnnouncer writes plain text sentences about each bar's progress,
+// like "download 45 percent, 2 minutes remaining", to w at most once
+// per interval, so screen readers, which can't follow repainted bars,
+// can keep up. Completed and aborted bars are announced once. To use
+// announcements instead of the live area, combine with
+// WithOutput(ioutil.Discard).
+func WithAnnouncer(w io.Writer, interval time.Duration) ContainerOption {
+	if w == nil {
+		return nil
+	}
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	return func(s *pState) {
+		s.announcer = &announcer{w: w, interval: interval}
+	}
+}
+
+// WithSummary prints a summary line, produced by fn, right after all
+// bars have been rendered for the last time. For example:
+//
+//	mpb.WithSummary(func(s mpb.Summary) string {
+//		return fmt.Sprintf("%d tasks, % .1f in %s, avg % .1f",
+//			len(s.Bars), decor.SizeB1024(s.Current), s.Elapsed.Round(time.Second),
+//			decor.FmtAsSpeed(decor.SizeB1024(s.Speed())))
+//	})
+//
+func WithSummary(fn func(Summary) string) ContainerOption {
+	if fn == nil {
+		return nil
+	}
+	return func(s *pState) {
+		s.summary = fn
+	}
+}
+
+// WithTitleProgress reports overall progress percentage into terminal
+// title, by means of OSC 2 sequence. Previous title is restored on
+// shutdown, if terminal supports title stack. Has no effect, if output
+// is not a terminal.
+//
+//	`format` printf compatible verb for int percentage, like "copying %d%%"
+//
+func WithTitleProgress(format string) ContainerOption {
+	if format == "" {
+		format = "%d%%"
+	}
+	return func(s *pState) {
+		if s.termProgress == nil {
+			s.termProgress = new(termProgress)
+		}
+		s.termProgress.title = true
+		s.termProgress.titleFormat = format
+	}
+}
+
+// WithTaskbarProgress reports overall progress percentage into
+// taskbar, by means of OSC 9;4 sequence, supported by Windows Terminal
+// and ConEmu. Has no effect, if output is not a terminal.
+func WithTaskbarProgress() ContainerOption {
+	return func(s *pState) {
+		if s.termProgress == nil {
+			s.termProgress = new(termProgress)
+		}
+		s.termProgress.taskbar = true
+	}
+}
+
+// WithCompletionNotifier calls fn once, right after all bars have been
+// rendered for the last time, i.e. when all bars are either completed
+// or aborted. Container's output is passed to fn, so it's possible to
+// emit an escape sequence, see OSCNotifier, or to alert user by other
+// means, for example by running a desktop notification command.
+func WithCompletionNotifier(fn CompletionNotifier) ContainerOption {
+	if fn == nil {
+		return nil
+	}
+	return func(s *pState) {
+		s.notifiers = append(s.notifiers, fn)
+	}
+}
+
 // PopCompletedMode will pop and stop rendering completed bars.
 func PopCompletedMode() ContainerOption {
 	return func(s *pState) {
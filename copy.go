@@ -0,0 +1,20 @@
+package mpb
+
+import "io"
+
+// Copy copies from src to dst until either EOF is reached on src or an
+// error occurs, driving bar by amount of bytes copied. It's a
+// replacement of io.Copy plus *Bar.ProxyReader boilerplate. On success
+// bar is completed, with its total set to amount of bytes copied, so
+// bar's total may be just an estimate of src size. On error bar is
+// aborted, without being dropped. Returns number of bytes copied and
+// the first error encountered while copying, if any.
+func Copy(dst io.Writer, src io.Reader, bar *Bar) (int64, error) {
+	n, err := io.Copy(dst, bar.ProxyReader(src))
+	if err != nil {
+		bar.Abort(false)
+		return n, err
+	}
+	bar.SetTotal(-1, true)
+	return n, nil
+}
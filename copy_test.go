@@ -0,0 +1,63 @@
+package mpb_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/vbauerster/mpb/v5"
+)
+
+func TestCopy(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(ioutil.Discard))
+
+	// overestimated total, bar should complete on EOF anyway
+	bar := p.AddBar(int64(len(content)) * 2)
+
+	var buf bytes.Buffer
+	n, err := mpb.Copy(&buf, strings.NewReader(content), bar)
+	if err != nil {
+		t.Errorf("Error copying from reader: %+v\n", err)
+	}
+
+	p.Wait()
+
+	if n != int64(len(content)) {
+		t.Errorf("Expected copied: %d, got: %d\n", len(content), n)
+	}
+	if got := bar.Current(); got != n {
+		t.Errorf("Expected current: %d, got: %d\n", n, got)
+	}
+}
+
+func TestCopyError(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(ioutil.Discard))
+
+	bar := p.AddBar(int64(len(content)) * 2)
+
+	errBroken := errors.New("broken")
+	src := io.MultiReader(strings.NewReader(content), &errReader{errBroken})
+
+	_, err := mpb.Copy(ioutil.Discard, src, bar)
+	if err != errBroken {
+		t.Errorf("Expected error: %v, got: %v\n", errBroken, err)
+	}
+
+	// bar is aborted, so Wait shouldn't block
+	p.Wait()
+
+	if got := bar.Current(); got != int64(len(content)) {
+		t.Errorf("Expected current: %d, got: %d\n", len(content), got)
+	}
+}
+
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -23,6 +23,7 @@ type Writer struct {
 	out        io.Writer
 	buf        bytes.Buffer
 	lineCount  int
+	lastByte   byte
 	fd         int
 	isTerminal bool
 }
@@ -47,10 +48,54 @@ func (w *Writer) Flush(lineCount int) (err error) {
 		}
 	}
 	w.lineCount = lineCount
+	if b := trimTrailingEscapes(w.buf.Bytes()); len(b) != 0 {
+		w.lastByte = b[len(b)-1]
+	}
 	_, err = w.buf.WriteTo(w.out)
 	return
 }
 
+// EndsWithNewline reports whether flushed output, if any, ends with
+// a newline, i.e. whether cursor is at the start of a line.
+func (w *Writer) EndsWithNewline() bool {
+	return w.lastByte == 0 || w.lastByte == '\n'
+}
+
+// trimTrailingEscapes strips escape sequences, which don't move the
+// cursor, i.e. OSC, like window title, and CSI SGR or window
+// manipulation, off the end of b.
+func trimTrailingEscapes(b []byte) []byte {
+	for {
+		i := bytes.LastIndexByte(b, '\x1b')
+		if i < 0 {
+			return b
+		}
+		tail := b[i:]
+		switch {
+		case bytes.Equal(tail, []byte("\x1b\\")):
+			// string terminator of OSC
+			i = bytes.LastIndex(b[:i], []byte("\x1b]"))
+			if i < 0 {
+				return b
+			}
+		case bytes.HasPrefix(tail, []byte("\x1b]")) && tail[len(tail)-1] == '\a':
+		case bytes.HasPrefix(tail, []byte(escOpen)) && len(tail) > 2:
+			final := tail[len(tail)-1]
+			if final != 'm' && final != 't' {
+				return b
+			}
+			for _, c := range tail[2 : len(tail)-1] {
+				if (c < '0' || c > '9') && c != ';' {
+					return b
+				}
+			}
+		default:
+			return b
+		}
+		b = b[:i]
+	}
+}
+
 // Write appends the contents of p to the underlying buffer.
 func (w *Writer) Write(p []byte) (n int, err error) {
 	return w.buf.Write(p)
@@ -1,21 +1,31 @@
 package decor
 
 // Any decorator displays text, that can be changed during decorator's
-// lifetime via provided DecorFunc.
+// lifetime via provided DecorFunc. If DTruncate bit is set and WC.W
+// is positive, text wider than WC.W is truncated with "…" tail.
 //
 //	`fn` DecorFunc callback
 //
 //	`wcc` optional WC config
 //
 func Any(fn DecorFunc, wcc ...WC) Decorator {
-	return &any{initWC(wcc...), fn}
+	return &any{initWC(wcc...), fn, "…"}
 }
 
 type any struct {
 	WC
-	fn DecorFunc
+	fn   DecorFunc
+	tail string
 }
 
 func (d *any) Decor(s Statistics) string {
-	return d.FormatMsg(d.fn(s))
+	msg := d.M.ExpandTabs(d.fn(s))
+	if (d.C&DTruncate) != 0 && d.W > 0 {
+		msg = d.M.TruncateString(msg, d.W, d.tail)
+	}
+	return d.FormatMsg(msg)
+}
+
+func (d *any) AdaptASCII() {
+	d.tail = "..."
 }
@@ -0,0 +1,24 @@
+package decor
+
+import "fmt"
+
+// Attempt decorator displays bar's attempt number, which is
+// incremented by each *Bar.Reset call. Nothing is displayed for the
+// first attempt.
+//
+//	`format` printf compatible verb for int value, like "attempt %d"
+//
+//	`wcc` optional WC config
+//
+func Attempt(format string, wcc ...WC) Decorator {
+	if format == "" {
+		format = "attempt %d"
+	}
+	fn := func(s Statistics) string {
+		if s.Attempt <= 1 {
+			return ""
+		}
+		return fmt.Sprintf(format, s.Attempt)
+	}
+	return Any(fn, wcc...)
+}
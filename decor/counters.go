@@ -11,6 +11,34 @@ const (
 	UnitKB
 )
 
+// Unit modifiers, which may be combined with UnitKiB or UnitKB by
+// bitwise OR. Fixed modifiers keep size marker constant, so columns
+// don't change width mid-transfer, for example:
+//
+//	unit=UnitKiB|UnitFixedM, format="%.1f" output: "0.5MiB", "1024.0MiB"
+//
+const (
+	UnitFixedK = (iota + 1) << 4
+	UnitFixedM
+	UnitFixedG
+	UnitFixedT
+)
+
+const (
+	unitBaseMask  = 0xf
+	unitFixedMask = 0xf0
+)
+
+// UnitThreshold returns unit modifier, which delays switching to the
+// next size marker until value reaches n of it, for example
+// UnitKiB|UnitThreshold(10) keeps KiB until 10 MiB.
+func UnitThreshold(n int) int {
+	if n < 1 {
+		n = 1
+	}
+	return n << 8
+}
+
 // CountersNoUnit is a wrapper around Counters with no unit param.
 func CountersNoUnit(pairFmt string, wcc ...WC) Decorator {
 	return Counters(0, pairFmt, wcc...)
@@ -22,6 +50,27 @@ func CountersKibiByte(pairFmt string, wcc ...WC) Decorator {
 	return Counters(UnitKiB, pairFmt, wcc...)
 }
 
+// CountersFloat displays unscaled current and total pair of a bar
+// with fractional progress, see mpb.BarFloatScale.
+//
+//	`pairFmt` printf compatible verbs for float current and total pair
+//
+//	`wcc` optional WC config
+//
+// pairFmt example:
+//
+//	pairFmt="%.2f / %.2f" output: "0.25 / 1.50"
+//
+func CountersFloat(pairFmt string, wcc ...WC) Decorator {
+	if pairFmt == "" {
+		pairFmt = "%.2f / %.2f"
+	}
+	fn := func(s Statistics) string {
+		return fmt.Sprintf(pairFmt, s.CurrentFloat(), s.TotalFloat())
+	}
+	return Any(fn, wcc...)
+}
+
 // CountersKiloByte is a wrapper around Counters with predefined unit
 // UnitKB (bytes/1000).
 func CountersKiloByte(pairFmt string, wcc ...WC) Decorator {
@@ -50,20 +99,14 @@ func Counters(unit int, pairFmt string, wcc ...WC) Decorator {
 		} else if strings.Count(pairFmt, "%") != 2 {
 			panic("expected pairFmt with exactly 2 verbs")
 		}
-		switch unit {
-		case UnitKiB:
-			return func(s Statistics) string {
-				return fmt.Sprintf(pairFmt, SizeB1024(s.Current), SizeB1024(s.Total))
-			}
-		case UnitKB:
-			return func(s Statistics) string {
-				return fmt.Sprintf(pairFmt, SizeB1000(s.Current), SizeB1000(s.Total))
-			}
-		default:
+		if size := sizeFormatter(unit); size != nil {
 			return func(s Statistics) string {
-				return fmt.Sprintf(pairFmt, s.Current, s.Total)
+				return fmt.Sprintf(pairFmt, size(s.Current), size(s.Total))
 			}
 		}
+		return func(s Statistics) string {
+			return fmt.Sprintf(pairFmt, s.Current, s.Total)
+		}
 	}
 	return Any(producer(unit, pairFmt), wcc...)
 }
@@ -108,20 +151,14 @@ func Total(unit int, format string, wcc ...WC) Decorator {
 			panic("expected format with exactly 1 verb")
 		}
 
-		switch unit {
-		case UnitKiB:
-			return func(s Statistics) string {
-				return fmt.Sprintf(format, SizeB1024(s.Total))
-			}
-		case UnitKB:
+		if size := sizeFormatter(unit); size != nil {
 			return func(s Statistics) string {
-				return fmt.Sprintf(format, SizeB1000(s.Total))
-			}
-		default:
-			return func(s Statistics) string {
-				return fmt.Sprintf(format, s.Total)
+				return fmt.Sprintf(format, size(s.Total))
 			}
 		}
+		return func(s Statistics) string {
+			return fmt.Sprintf(format, s.Total)
+		}
 	}
 	return Any(producer(unit, format), wcc...)
 }
@@ -166,20 +203,14 @@ func Current(unit int, format string, wcc ...WC) Decorator {
 			panic("expected format with exactly 1 verb")
 		}
 
-		switch unit {
-		case UnitKiB:
-			return func(s Statistics) string {
-				return fmt.Sprintf(format, SizeB1024(s.Current))
-			}
-		case UnitKB:
-			return func(s Statistics) string {
-				return fmt.Sprintf(format, SizeB1000(s.Current))
-			}
-		default:
+		if size := sizeFormatter(unit); size != nil {
 			return func(s Statistics) string {
-				return fmt.Sprintf(format, s.Current)
+				return fmt.Sprintf(format, size(s.Current))
 			}
 		}
+		return func(s Statistics) string {
+			return fmt.Sprintf(format, s.Current)
+		}
 	}
 	return Any(producer(unit, format), wcc...)
 }
@@ -224,20 +255,74 @@ func InvertedCurrent(unit int, format string, wcc ...WC) Decorator {
 			panic("expected format with exactly 1 verb")
 		}
 
-		switch unit {
-		case UnitKiB:
-			return func(s Statistics) string {
-				return fmt.Sprintf(format, SizeB1024(s.Total-s.Current))
-			}
-		case UnitKB:
-			return func(s Statistics) string {
-				return fmt.Sprintf(format, SizeB1000(s.Total-s.Current))
-			}
-		default:
+		if size := sizeFormatter(unit); size != nil {
 			return func(s Statistics) string {
-				return fmt.Sprintf(format, s.Total-s.Current)
+				return fmt.Sprintf(format, size(s.Total-s.Current))
 			}
 		}
+		return func(s Statistics) string {
+			return fmt.Sprintf(format, s.Total-s.Current)
+		}
 	}
 	return Any(producer(unit, format), wcc...)
 }
+
+// RemainingNoUnit is a wrapper around Remaining with no unit param.
+func RemainingNoUnit(format string, wcc ...WC) Decorator {
+	return Remaining(0, format, wcc...)
+}
+
+// RemainingKibiByte is a wrapper around Remaining with predefined unit
+// UnitKiB (bytes/1024).
+func RemainingKibiByte(format string, wcc ...WC) Decorator {
+	return Remaining(UnitKiB, format, wcc...)
+}
+
+// RemainingKiloByte is a wrapper around Remaining with predefined unit
+// UnitKB (bytes/1000).
+func RemainingKiloByte(format string, wcc ...WC) Decorator {
+	return Remaining(UnitKB, format, wcc...)
+}
+
+// Remaining decorator displays what's left rather than what's done,
+// with dynamic unit measure adjustment. Unlike InvertedCurrent it
+// never goes below zero, even if bar's current exceeds its total.
+//
+//	`unit` one of [0|UnitKiB|UnitKB] zero for no unit
+//
+//	`format` printf compatible verb for Remaining, if empty "%d left"
+//	is used without unit and "% .1f left" with unit
+//
+//	`wcc` optional WC config
+//
+// format examples:
+//
+//	unit=UnitKiB, format=""              output: "1.4 GiB left"
+//	unit=UnitKiB, format="%.1f"          output: "1.4GiB"
+//	unit=0,       format="%d files left" output: "235 files left"
+//
+func Remaining(unit int, format string, wcc ...WC) Decorator {
+	size := sizeFormatter(unit)
+	if format == "" {
+		format = "%d left"
+		if size != nil {
+			format = "% .1f left"
+		}
+	} else if strings.Count(format, "%") != 1 {
+		panic("expected format with exactly 1 verb")
+	}
+	remaining := func(s Statistics) int64 {
+		if n := s.Total - s.Current; n > 0 {
+			return n
+		}
+		return 0
+	}
+	if size != nil {
+		return Any(func(s Statistics) string {
+			return fmt.Sprintf(format, size(remaining(s)))
+		}, wcc...)
+	}
+	return Any(func(s Statistics) string {
+		return fmt.Sprintf(format, remaining(s))
+	}, wcc...)
+}
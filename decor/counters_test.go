@@ -0,0 +1,23 @@
+package decor
+
+import "testing"
+
+func TestRemaining(t *testing.T) {
+	cases := map[string]struct {
+		d        Decorator
+		stat     Statistics
+		expected string
+	}{
+		"no unit":      {RemainingNoUnit(""), Statistics{Total: 300, Current: 65}, "235 left"},
+		"custom":       {RemainingNoUnit("%d files left"), Statistics{Total: 300, Current: 65}, "235 files left"},
+		"kibibyte":     {RemainingKibiByte(""), Statistics{Total: 3 * int64(_iGiB), Current: int64(_iGiB) + int64(_iGiB)*6/10}, "1.4 GiB left"},
+		"kilobyte":     {RemainingKiloByte("%.1f"), Statistics{Total: 3 * int64(_GB), Current: int64(_GB)}, "2.0GB"},
+		"overflow":     {RemainingNoUnit(""), Statistics{Total: 10, Current: 12}, "0 left"},
+		"dynamic unit": {RemainingKibiByte("% .0f"), Statistics{Total: 2048, Current: 1024}, "1 KiB"},
+	}
+	for name, tc := range cases {
+		if got := tc.d.Decor(tc.stat); got != tc.expected {
+			t.Errorf("%s: expected: %q, got: %q", name, tc.expected, got)
+		}
+	}
+}
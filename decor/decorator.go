@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/acarl005/stripansi"
-	"github.com/mattn/go-runewidth"
 )
 
 const (
@@ -23,6 +22,24 @@ const (
 	// Effective with multiple bars only.
 	DSyncWidth
 
+	// DZeroPad bit pads message with zeros, inserted in front of the
+	// first number, instead of spaces. Identation direction is ignored
+	// if message contains a number.
+	// |0012.5MiB| With DZeroPad and W: 9
+	DZeroPad
+
+	// DFixedPrec bit reformats every number of the message with
+	// exactly WC.P decimal places.
+	DFixedPrec
+
+	// DThousands bit inserts thousands separator into integer part of
+	// every number of the message.
+	DThousands
+
+	// DTruncate bit truncates message of Any or Name decorator to fit
+	// into WC.W cells, see TruncateString.
+	DTruncate
+
 	// DSyncWidthR is shortcut for DSyncWidth|DidentRight
 	DSyncWidthR = DSyncWidth | DidentRight
 
@@ -45,14 +62,46 @@ const (
 )
 
 // Statistics consists of progress related statistics, that Decorator
-// may need.
+// may need. It's a snapshot of bar's state, taken once per frame, so
+// all decorators and the filler of a bar observe the same values
+// within a frame, no matter how many goroutines increment the bar
+// concurrently.
 type Statistics struct {
 	ID             int
+	Name           string
 	AvailableWidth int
 	Total          int64
 	Current        int64
 	Refill         int64
+	Overflow       int64
+	Errors         int64
+	LastUpdate     time.Time
+	Elapsed        time.Duration
+	ActiveElapsed  time.Duration
+	Paused         bool
+	Attempt        int
+	Scale          float64
 	Completed      bool
+	Aborted        bool
+	Pending        bool
+}
+
+// CurrentFloat returns Current divided by Scale, i.e. unscaled
+// fractional progress of a bar with float scale.
+func (s Statistics) CurrentFloat() float64 {
+	return float64(s.Current) / s.scale()
+}
+
+// TotalFloat returns Total divided by Scale.
+func (s Statistics) TotalFloat() float64 {
+	return float64(s.Total) / s.scale()
+}
+
+func (s Statistics) scale() float64 {
+	if s.Scale <= 0 {
+		return 1
+	}
+	return s.Scale
 }
 
 // Decorator interface.
@@ -107,6 +156,22 @@ type AverageDecorator interface {
 	AverageAdjust(time.Time)
 }
 
+// ResetDecorator interface.
+// Decorators, which accumulate state during bar's progress, like
+// moving average based ones, should implement this interface, in
+// order to start from scratch after *Bar.Reset.
+type ResetDecorator interface {
+	Reset()
+}
+
+// ASCIIAdapter interface.
+// Decorators, which render unicode by default, like Spinner, should
+// implement this interface, in order to fall back to ASCII on
+// terminals without unicode support or if ASCII only mode is forced.
+type ASCIIAdapter interface {
+	AdaptASCII()
+}
+
 // ShutdownListener interface.
 // If decorator needs to be notified once upon bar shutdown event, so
 // this is the right interface to implement.
@@ -123,12 +188,16 @@ var (
 	WCSyncSpaceR = WC{C: DSyncSpaceR}
 )
 
-// WC is a struct with two public fields W and C, both of int type.
+// WC is a struct with public fields W, C and P, all of int type, and M.
 // W represents width and C represents bit set of width related config.
+// P represents number of decimal places, effective with DFixedPrec bit.
+// M represents Metrics, display width of text is measured with.
 // A decorator should embed WC, to enable width synchronization.
 type WC struct {
 	W     int
 	C     int
+	P     int
+	M     Metrics
 	fill  func(s string, w int) string
 	wsync chan int
 }
@@ -136,8 +205,12 @@ type WC struct {
 // FormatMsg formats final message according to WC.W and WC.C.
 // Should be called by any Decorator implementation.
 func (wc *WC) FormatMsg(msg string) string {
-	pureWidth := runewidth.StringWidth(msg)
-	stripWidth := runewidth.StringWidth(stripansi.Strip(msg))
+	msg = wc.M.ExpandTabs(msg)
+	if (wc.C & (DFixedPrec | DThousands)) != 0 {
+		msg = formatNumbers(msg, wc.C, wc.P)
+	}
+	pureWidth := wc.M.StringWidth(msg)
+	stripWidth := wc.M.StringWidth(stripansi.Strip(msg))
 	maxCell := wc.W
 	if (wc.C & DSyncWidth) != 0 {
 		cellCount := stripWidth
@@ -152,9 +225,12 @@ func (wc *WC) FormatMsg(msg string) string {
 
 // Init initializes width related config.
 func (wc *WC) Init() WC {
-	wc.fill = runewidth.FillLeft
+	wc.fill = wc.M.fillLeft
 	if (wc.C & DidentRight) != 0 {
-		wc.fill = runewidth.FillRight
+		wc.fill = wc.M.fillRight
+	}
+	if (wc.C & DZeroPad) != 0 {
+		wc.fill = zeroFill(wc.M, wc.fill)
 	}
 	if (wc.C & DSyncWidth) != 0 {
 		// it's deliberate choice to override wsync on each Init() call,
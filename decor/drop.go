@@ -0,0 +1,37 @@
+package decor
+
+// Dropper interface.
+// Decorators, which may be omitted by compact layout on narrow
+// terminals, implement this interface. See DropPriority.
+type Dropper interface {
+	DropPriority() int
+}
+
+// DropPriority returns decorator, which wraps provided decorator, with
+// sole purpose to mark it as droppable by compact layout, see
+// mpb.BarCompactLayout. Decorators with greater priority value are
+// dropped first, decorators without priority are never dropped.
+//
+//	`decorator` Decorator to wrap
+//
+//	`priority` drop priority, greater value is dropped first
+//
+func DropPriority(decorator Decorator, priority int) Decorator {
+	return &dropWrapper{
+		Decorator: decorator,
+		priority:  priority,
+	}
+}
+
+type dropWrapper struct {
+	Decorator
+	priority int
+}
+
+func (d *dropWrapper) DropPriority() int {
+	return d.priority
+}
+
+func (d *dropWrapper) Base() Decorator {
+	return d.Decorator
+}
@@ -1,6 +1,7 @@
 package decor
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -33,3 +34,42 @@ func NewElapsed(style TimeStyle, startTime time.Time, wcc ...WC) Decorator {
 	}
 	return Any(fn, wcc...)
 }
+
+// ActiveElapsed decorator displays bar's active time, i.e. time
+// passed since bar's start excluding periods when bar was paused by
+// *Bar.Pause() or the process was suspended.
+//
+//	`style` one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_HHMM|ET_STYLE_MMSS]
+//
+//	`wcc` optional WC config
+//
+func ActiveElapsed(style TimeStyle, wcc ...WC) Decorator {
+	producer := chooseTimeProducer(style)
+	fn := func(s Statistics) string {
+		return producer(s.ActiveElapsed)
+	}
+	return Any(fn, wcc...)
+}
+
+// SinceLastUpdate decorator displays time passed since bar's last
+// increment, which is handy to spot stuck workers in long running
+// jobs.
+//
+//	`format` printf compatible verb for string value, like "idle %s"
+//
+//	`wcc` optional WC config
+//
+func SinceLastUpdate(format string, wcc ...WC) Decorator {
+	if format == "" {
+		format = "%s"
+	}
+	var msg string
+	producer := chooseTimeProducer(ET_STYLE_GO)
+	fn := func(s Statistics) string {
+		if !s.Completed && !s.LastUpdate.IsZero() {
+			msg = fmt.Sprintf(format, producer(time.Since(s.LastUpdate)))
+		}
+		return msg
+	}
+	return Any(fn, wcc...)
+}
@@ -0,0 +1,26 @@
+package decor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSinceLastUpdate(t *testing.T) {
+	d := SinceLastUpdate("idle %s")
+
+	if got := d.Decor(Statistics{}); got != "" {
+		t.Errorf("before first update: expected empty, got: %q", got)
+	}
+
+	st := Statistics{LastUpdate: time.Now().Add(-90 * time.Second)}
+	if got := d.Decor(st); got != "idle 1m30s" {
+		t.Errorf("expected: %q, got: %q", "idle 1m30s", got)
+	}
+
+	// frozen once completed
+	st.LastUpdate = time.Now()
+	st.Completed = true
+	if got := d.Decor(st); got != "idle 1m30s" {
+		t.Errorf("after completion: expected: %q, got: %q", "idle 1m30s", got)
+	}
+}
@@ -0,0 +1,20 @@
+package decor
+
+import "fmt"
+
+// Errors decorator displays bar's error counter, which is incremented
+// by *Bar.IncrError calls.
+//
+//	`format` printf compatible verb for int64 value, like "errs: %d"
+//
+//	`wcc` optional WC config
+//
+func Errors(format string, wcc ...WC) Decorator {
+	if format == "" {
+		format = "errs: %d"
+	}
+	fn := func(s Statistics) string {
+		return fmt.Sprintf(format, s.Errors)
+	}
+	return Any(fn, wcc...)
+}
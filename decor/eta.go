@@ -3,9 +3,9 @@ package decor
 import (
 	"fmt"
 	"math"
+	"strings"
 	"time"
-
-	"github.com/VividCortex/ewma"
+	"unicode"
 )
 
 // TimeNormalizer interface. Implementors could be passed into
@@ -27,13 +27,10 @@ func (f TimeNormalizerFunc) Normalize(src time.Duration) time.Duration {
 // iteration's duration and pass it to the
 // *Bar.DecoratorEwmaUpdate(time.Duration) method after each increment.
 func EwmaETA(style TimeStyle, age float64, wcc ...WC) Decorator {
-	var average ewma.MovingAverage
-	if age == 0 {
-		average = ewma.NewMovingAverage()
-	} else {
-		average = ewma.NewMovingAverage(age)
-	}
-	return MovingAverageETA(style, NewThreadSafeMovingAverage(average), nil, wcc...)
+	newAverage := newEwma(age)
+	d := MovingAverageETA(style, newAverage(), nil, wcc...).(*movingAverageETA)
+	d.newAverage = newAverage
+	return d
 }
 
 // MovingAverageETA decorator relies on MovingAverage implementation to calculate its average.
@@ -46,7 +43,7 @@ func EwmaETA(style TimeStyle, age float64, wcc ...WC) Decorator {
 //
 //	`wcc` optional WC config
 //
-func MovingAverageETA(style TimeStyle, average ewma.MovingAverage, normalizer TimeNormalizer, wcc ...WC) Decorator {
+func MovingAverageETA(style TimeStyle, average MovingAverage, normalizer TimeNormalizer, wcc ...WC) Decorator {
 	d := &movingAverageETA{
 		WC:         initWC(wcc...),
 		average:    average,
@@ -58,9 +55,11 @@ func MovingAverageETA(style TimeStyle, average ewma.MovingAverage, normalizer Ti
 
 type movingAverageETA struct {
 	WC
-	average    ewma.MovingAverage
+	average    MovingAverage
+	newAverage func() MovingAverage
 	normalizer TimeNormalizer
 	producer   func(time.Duration) string
+	clock      *wallClock
 }
 
 func (d *movingAverageETA) Decor(s Statistics) string {
@@ -69,6 +68,9 @@ func (d *movingAverageETA) Decor(s Statistics) string {
 	if d.normalizer != nil {
 		remaining = d.normalizer.Normalize(remaining)
 	}
+	if d.clock != nil {
+		return d.FormatMsg(d.clock.format(s, remaining, v > 0))
+	}
 	return d.FormatMsg(d.producer(remaining))
 }
 
@@ -80,6 +82,76 @@ func (d *movingAverageETA) EwmaUpdate(n int64, dur time.Duration) {
 	d.average.Add(durPerItem)
 }
 
+func (d *movingAverageETA) Reset() {
+	d.average = resetAverage(d.average, d.newAverage)
+	if d.clock != nil {
+		d.clock.msg = ""
+	}
+}
+
+// EwmaETAWallClock exponential-weighted-moving-average based decorator,
+// which displays projected completion time, like "15:04", instead of
+// remaining duration. Same EWMA contract as for EwmaETA applies. Time
+// of completion is frozen once bar is completed, and placeholder, like
+// "--:--", is displayed while there is no estimate yet. The same goes
+// for other wall clock ETA decorators.
+//
+//	`layout` time layout, see time.Format, "15:04" if empty
+//
+//	`loc` time zone to display time in, time.Local if nil
+//
+//	`age` ewma age
+//
+//	`wcc` optional WC config
+//
+func EwmaETAWallClock(layout string, loc *time.Location, age float64, wcc ...WC) Decorator {
+	newAverage := newEwma(age)
+	d := MovingAverageETAWallClock(layout, loc, newAverage(), nil, wcc...).(*movingAverageETA)
+	d.newAverage = newAverage
+	return d
+}
+
+// MovingAverageETAWallClock same as MovingAverageETA, but displays
+// projected completion time instead of remaining duration.
+//
+//	`layout` time layout, see time.Format, "15:04" if empty
+//
+//	`loc` time zone to display time in, time.Local if nil
+//
+//	`average` implementation of MovingAverage interface
+//
+//	`normalizer` available implementations are [FixedIntervalTimeNormalizer|MaxTolerateTimeNormalizer]
+//
+//	`wcc` optional WC config
+//
+func MovingAverageETAWallClock(layout string, loc *time.Location, average MovingAverage, normalizer TimeNormalizer, wcc ...WC) Decorator {
+	d := &movingAverageETA{
+		WC:         initWC(wcc...),
+		average:    average,
+		normalizer: normalizer,
+		clock:      newWallClock(layout, loc),
+	}
+	return d
+}
+
+// AverageETAWallClock same as AverageETA, but displays projected
+// completion time instead of remaining duration.
+//
+//	`layout` time layout, see time.Format, "15:04" if empty
+//
+//	`loc` time zone to display time in, time.Local if nil
+//
+//	`wcc` optional WC config
+//
+func AverageETAWallClock(layout string, loc *time.Location, wcc ...WC) Decorator {
+	d := &averageETA{
+		WC:        initWC(wcc...),
+		startTime: time.Now(),
+		clock:     newWallClock(layout, loc),
+	}
+	return d
+}
+
 // AverageETA decorator. It's wrapper of NewAverageETA.
 //
 //	`style` one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_HHMM|ET_STYLE_MMSS]
@@ -115,6 +187,7 @@ type averageETA struct {
 	startTime  time.Time
 	normalizer TimeNormalizer
 	producer   func(time.Duration) string
+	clock      *wallClock
 }
 
 func (d *averageETA) Decor(s Statistics) string {
@@ -127,6 +200,9 @@ func (d *averageETA) Decor(s Statistics) string {
 			remaining = d.normalizer.Normalize(remaining)
 		}
 	}
+	if d.clock != nil {
+		return d.FormatMsg(d.clock.format(s, remaining, s.Current != 0))
+	}
 	return d.FormatMsg(d.producer(remaining))
 }
 
@@ -134,6 +210,33 @@ func (d *averageETA) AverageAdjust(startTime time.Time) {
 	d.startTime = startTime
 }
 
+// ActiveAverageETA decorator. Unlike AverageETA it relies on bar's
+// active time, so periods when bar was paused or the process was
+// suspended don't affect the estimate.
+//
+//	`style` one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_HHMM|ET_STYLE_MMSS]
+//
+//	`normalizer` available implementations are [FixedIntervalTimeNormalizer|MaxTolerateTimeNormalizer]
+//
+//	`wcc` optional WC config
+//
+func ActiveAverageETA(style TimeStyle, normalizer TimeNormalizer, wcc ...WC) Decorator {
+	producer := chooseTimeProducer(style)
+	fn := func(s Statistics) string {
+		var remaining time.Duration
+		if s.Current != 0 {
+			durPerItem := float64(s.ActiveElapsed) / float64(s.Current)
+			durPerItem = math.Round(durPerItem)
+			remaining = time.Duration((s.Total - s.Current) * int64(durPerItem))
+			if normalizer != nil {
+				remaining = normalizer.Normalize(remaining)
+			}
+		}
+		return producer(remaining)
+	}
+	return Any(fn, wcc...)
+}
+
 // MaxTolerateTimeNormalizer returns implementation of TimeNormalizer.
 func MaxTolerateTimeNormalizer(maxTolerate time.Duration) TimeNormalizer {
 	var normalized time.Duration
@@ -169,6 +272,50 @@ func FixedIntervalTimeNormalizer(updInterval int) TimeNormalizer {
 	})
 }
 
+// wallClock formats projected completion time. Completion time is
+// frozen, once bar is completed, and placeholder of layout's width is
+// shown, while there is no estimate yet.
+type wallClock struct {
+	layout      string
+	loc         *time.Location
+	placeholder string
+	msg         string
+}
+
+func newWallClock(layout string, loc *time.Location) *wallClock {
+	if layout == "" {
+		layout = "15:04"
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	placeholder := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return '-'
+		}
+		return r
+	}, time.Now().In(loc).Format(layout))
+	return &wallClock{
+		layout:      layout,
+		loc:         loc,
+		placeholder: placeholder,
+	}
+}
+
+func (c *wallClock) format(s Statistics, remaining time.Duration, known bool) string {
+	switch {
+	case s.Completed:
+		if c.msg == "" {
+			c.msg = time.Now().In(c.loc).Format(c.layout)
+		}
+		return c.msg
+	case !known:
+		return c.placeholder
+	default:
+		return time.Now().Add(remaining).In(c.loc).Format(c.layout)
+	}
+}
+
 func chooseTimeProducer(style TimeStyle) func(time.Duration) string {
 	switch style {
 	case ET_STYLE_HHMMSS:
@@ -0,0 +1,63 @@
+package decor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VividCortex/ewma"
+)
+
+func TestMovingAverageETAWallClock(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	average := ewma.NewMovingAverage()
+	d := MovingAverageETAWallClock(time.RFC3339, loc, average, nil)
+
+	// one item per second, 90 items remaining
+	average.Add(float64(time.Second))
+	before := time.Now().Add(90 * time.Second).Truncate(time.Second)
+	got, err := time.Parse(time.RFC3339, d.Decor(Statistics{Total: 100, Current: 10}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := time.Now().Add(90 * time.Second)
+
+	if got.Before(before) || got.After(after) {
+		t.Errorf("expected completion time in [%s, %s], got: %s", before, after, got)
+	}
+	if _, offset := got.Zone(); offset != 3*60*60 {
+		t.Errorf("expected offset %d, got: %d", 3*60*60, offset)
+	}
+}
+
+func TestETAWallClockPlaceholder(t *testing.T) {
+	for name, d := range map[string]Decorator{
+		"moving average": MovingAverageETAWallClock("15:04:05", nil, ewma.NewMovingAverage(), nil),
+		"average":        AverageETAWallClock("15:04:05", nil),
+	} {
+		if got := d.Decor(Statistics{Total: 100}); got != "--:--:--" {
+			t.Errorf("%s: expected placeholder %q, got: %q", name, "--:--:--", got)
+		}
+	}
+}
+
+func TestETAWallClockFrozenOnComplete(t *testing.T) {
+	average := ewma.NewMovingAverage()
+	average.Add(float64(time.Hour))
+	d := MovingAverageETAWallClock(time.RFC3339Nano, nil, average, nil)
+
+	st := Statistics{Total: 100, Current: 100, Completed: true}
+	before := time.Now()
+	first := d.Decor(st)
+	got, err := time.Parse(time.RFC3339Nano, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Before(before) || got.After(time.Now()) {
+		t.Errorf("expected completion time around now, got: %s", got)
+	}
+
+	time.Sleep(time.Millisecond)
+	if next := d.Decor(st); next != first {
+		t.Errorf("expected frozen %q, got: %q", first, next)
+	}
+}
@@ -0,0 +1,124 @@
+package decor
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	histMin     = 1e-3
+	histGrowth  = 1.1
+	histBuckets = 400
+)
+
+// SpeedHistogram is a lightweight histogram of per bar speeds. Each
+// bar contributes its average speed, so percentiles of the histogram
+// help to spot slow shards in parallel loads. Buckets are logarithmic,
+// so reported values are accurate within 10%.
+type SpeedHistogram struct {
+	mu     sync.Mutex
+	counts [histBuckets]int
+	n      int
+}
+
+// NewSpeedHistogram creates an empty SpeedHistogram.
+func NewSpeedHistogram() *SpeedHistogram {
+	return new(SpeedHistogram)
+}
+
+// Sampler returns zero width decorator, which feeds average speed of a
+// bar it's added to into the histogram. Add a new sampler to each bar
+// of interest. Speed of a completed bar stays in the histogram.
+func (h *SpeedHistogram) Sampler() Decorator {
+	bucket := -1
+	fn := func(s Statistics) string {
+		if s.Completed || s.ActiveElapsed <= 0 {
+			return ""
+		}
+		b := histBucket(float64(s.Current) / s.ActiveElapsed.Seconds())
+		if b == bucket {
+			return ""
+		}
+		h.mu.Lock()
+		if bucket < 0 {
+			h.n++
+		} else {
+			h.counts[bucket]--
+		}
+		h.counts[b]++
+		h.mu.Unlock()
+		bucket = b
+		return ""
+	}
+	return Any(fn)
+}
+
+// Percentile returns p-th percentile of sampled speeds in units per
+// second, p is expected to be in (0, 100] range.
+func (h *SpeedHistogram) Percentile(p float64) float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.n == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(h.n)))
+	if rank < 1 {
+		rank = 1
+	}
+	var seen int
+	for i, c := range h.counts {
+		seen += c
+		if seen >= rank {
+			return histValue(i)
+		}
+	}
+	return histValue(histBuckets - 1)
+}
+
+// SpeedPercentile decorator displays p-th percentile of speeds sampled
+// by h, meant to be used on a summary bar. For example, to display
+// median and 95th percentile:
+//
+//	decor.Name("p50 "), decor.SpeedPercentile(h, 50, decor.UnitKiB, "% .1f"),
+//	decor.Name(" p95 "), decor.SpeedPercentile(h, 95, decor.UnitKiB, "% .1f"),
+//
+//	`h` histogram fed by samplers
+//
+//	`p` percentile in (0, 100] range
+//
+//	`unit` one of [0|UnitKiB|UnitKB] zero for no unit
+//
+//	`format` printf compatible verb for value, like "%f" or "%d"
+//
+//	`wcc` optional WC config
+//
+func SpeedPercentile(h *SpeedHistogram, p float64, unit int, format string, wcc ...WC) Decorator {
+	if format == "" {
+		format = "%.0f"
+	}
+	producer := chooseSpeedProducer(unit, format, time.Second)
+	fn := func(Statistics) string {
+		return producer(h.Percentile(p))
+	}
+	return Any(fn, wcc...)
+}
+
+func histBucket(v float64) int {
+	if v < histMin {
+		return 0
+	}
+	i := 1 + int(math.Log(v/histMin)/math.Log(histGrowth))
+	if i >= histBuckets {
+		return histBuckets - 1
+	}
+	return i
+}
+
+// histValue returns geometric middle of bucket i.
+func histValue(i int) float64 {
+	if i == 0 {
+		return 0
+	}
+	return histMin * math.Pow(histGrowth, float64(i-1)+0.5)
+}
@@ -0,0 +1,36 @@
+package decor
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSpeedHistogram(t *testing.T) {
+	h := NewSpeedHistogram()
+	// 19 bars at 100 units/s and a slow one at 1 unit/s
+	for i := 0; i < 20; i++ {
+		current := int64(100)
+		if i == 0 {
+			current = 1
+		}
+		sampler := h.Sampler()
+		sampler.Decor(Statistics{Current: current, ActiveElapsed: time.Second})
+		// repeated sampling shouldn't count bar twice
+		sampler.Decor(Statistics{Current: current, ActiveElapsed: time.Second})
+	}
+
+	for _, tc := range []struct {
+		p    float64
+		want float64
+	}{
+		{5, 1},
+		{50, 100},
+		{95, 100},
+	} {
+		got := h.Percentile(tc.p)
+		if math.Abs(got-tc.want)/tc.want > 0.1 {
+			t.Errorf("p%.0f: want: %.1f±10%%, got: %.1f", tc.p, tc.want, got)
+		}
+	}
+}
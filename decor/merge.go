@@ -4,7 +4,6 @@ import (
 	"strings"
 
 	"github.com/acarl005/stripansi"
-	"github.com/mattn/go-runewidth"
 )
 
 // Merge wraps its decorator argument with intention to sync width
@@ -67,14 +66,14 @@ func (d *mergeDecorator) Base() Decorator {
 
 func (d *mergeDecorator) Decor(s Statistics) string {
 	msg := d.Decorator.Decor(s)
-	pureWidth := runewidth.StringWidth(msg)
-	stripWidth := runewidth.StringWidth(stripansi.Strip(msg))
+	pureWidth := d.wc.M.StringWidth(msg)
+	stripWidth := d.wc.M.StringWidth(stripansi.Strip(msg))
 	cellCount := stripWidth
 	if (d.wc.C & DextraSpace) != 0 {
 		cellCount++
 	}
 
-	total := runewidth.StringWidth(d.placeHolders[0].FormatMsg(""))
+	total := d.wc.M.StringWidth(d.placeHolders[0].FormatMsg(""))
 	pw := (cellCount - total) / len(d.placeHolders)
 	rem := (cellCount - total) % len(d.placeHolders)
 
@@ -88,7 +87,7 @@ func (d *mergeDecorator) Decor(s Statistics) string {
 				width = 0
 			}
 		}
-		max := runewidth.StringWidth(ph.FormatMsg(strings.Repeat(" ", width)))
+		max := d.wc.M.StringWidth(ph.FormatMsg(strings.Repeat(" ", width)))
 		total += max
 		diff = max - pw
 	}
@@ -7,8 +7,18 @@ import (
 	"github.com/VividCortex/ewma"
 )
 
+// MovingAverage is the interface that computes a moving average over
+// a time-series stream of numbers. The average may be over a window or
+// exponentially decaying. Its method set matches ewma.MovingAverage,
+// so implementations of github.com/VividCortex/ewma can be used as is.
+type MovingAverage interface {
+	Add(float64)
+	Value() float64
+	Set(float64)
+}
+
 type threadSafeMovingAverage struct {
-	ewma.MovingAverage
+	MovingAverage
 	mu sync.Mutex
 }
 
@@ -30,15 +40,53 @@ func (s *threadSafeMovingAverage) Set(value float64) {
 	s.mu.Unlock()
 }
 
-// NewThreadSafeMovingAverage converts provided ewma.MovingAverage
-// into thread safe ewma.MovingAverage.
-func NewThreadSafeMovingAverage(average ewma.MovingAverage) ewma.MovingAverage {
+func (s *threadSafeMovingAverage) reset() {
+	s.mu.Lock()
+	resetAverage(s.MovingAverage, nil)
+	s.mu.Unlock()
+}
+
+// NewThreadSafeMovingAverage converts provided MovingAverage into
+// thread safe MovingAverage.
+func NewThreadSafeMovingAverage(average MovingAverage) MovingAverage {
 	if tsma, ok := average.(*threadSafeMovingAverage); ok {
 		return tsma
 	}
 	return &threadSafeMovingAverage{MovingAverage: average}
 }
 
+// averageResetter is implemented by averages, which can drop added
+// samples and start over, as if just created.
+type averageResetter interface {
+	reset()
+}
+
+// resetAverage makes average start over. If newAverage is not nil,
+// fresh instance is returned. Otherwise average is reset in place,
+// Set(0) is the last resort for averages, which can't drop samples.
+func resetAverage(average MovingAverage, newAverage func() MovingAverage) MovingAverage {
+	if newAverage != nil {
+		return newAverage()
+	}
+	if r, ok := average.(averageResetter); ok {
+		r.reset()
+	} else {
+		average.Set(0)
+	}
+	return average
+}
+
+// newEwma returns constructor of thread safe EWMA of given age, zero
+// age means default one.
+func newEwma(age float64) func() MovingAverage {
+	return func() MovingAverage {
+		if age == 0 {
+			return NewThreadSafeMovingAverage(ewma.NewMovingAverage())
+		}
+		return NewThreadSafeMovingAverage(ewma.NewMovingAverage(age))
+	}
+}
+
 type medianWindow [3]float64
 
 func (s *medianWindow) Len() int           { return len(s) }
@@ -63,6 +111,96 @@ func (s *medianWindow) Set(value float64) {
 }
 
 // NewMedian is fixed last 3 samples median MovingAverage.
-func NewMedian() ewma.MovingAverage {
+func NewMedian() MovingAverage {
 	return NewThreadSafeMovingAverage(new(medianWindow))
 }
+
+// slidingWindow keeps last samples in a ring buffer, Value is computed
+// over added samples only, so there is no zero bias at start.
+type slidingWindow struct {
+	samples []float64
+	next    int
+	full    bool
+}
+
+func (s *slidingWindow) Add(value float64) {
+	s.samples[s.next] = value
+	s.next = (s.next + 1) % len(s.samples)
+	if s.next == 0 {
+		s.full = true
+	}
+}
+
+func (s *slidingWindow) window() []float64 {
+	if s.full {
+		return s.samples
+	}
+	return s.samples[:s.next]
+}
+
+func (s *slidingWindow) reset() {
+	s.next, s.full = 0, false
+}
+
+func (s *slidingWindow) Set(value float64) {
+	for i := range s.samples {
+		s.samples[i] = value
+	}
+	s.next, s.full = 0, true
+}
+
+type meanWindow struct {
+	slidingWindow
+}
+
+func (s *meanWindow) Value() float64 {
+	w := s.window()
+	if len(w) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range w {
+		sum += v
+	}
+	return sum / float64(len(w))
+}
+
+type medianSlidingWindow struct {
+	slidingWindow
+	tmp []float64
+}
+
+func (s *medianSlidingWindow) Value() float64 {
+	w := s.window()
+	if len(w) == 0 {
+		return 0
+	}
+	s.tmp = append(s.tmp[:0], w...)
+	sort.Float64s(s.tmp)
+	if n := len(s.tmp); n%2 == 0 {
+		return (s.tmp[n/2-1] + s.tmp[n/2]) / 2
+	}
+	return s.tmp[len(s.tmp)/2]
+}
+
+// NewSlidingWindow is simple moving average, i.e. arithmetic mean of
+// last size samples. Panics if size is less than 1.
+func NewSlidingWindow(size int) MovingAverage {
+	if size < 1 {
+		panic("window size must be positive")
+	}
+	return NewThreadSafeMovingAverage(&meanWindow{
+		slidingWindow{samples: make([]float64, size)},
+	})
+}
+
+// NewMedianWindow is median of last size samples, which is robust
+// against occasional spikes. Panics if size is less than 1.
+func NewMedianWindow(size int) MovingAverage {
+	if size < 1 {
+		panic("window size must be positive")
+	}
+	return NewThreadSafeMovingAverage(&medianSlidingWindow{
+		slidingWindow: slidingWindow{samples: make([]float64, size)},
+	})
+}
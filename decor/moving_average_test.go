@@ -0,0 +1,48 @@
+package decor
+
+import (
+	"testing"
+
+	"github.com/VividCortex/ewma"
+)
+
+// ewma implementations must satisfy MovingAverage as is
+var _ MovingAverage = ewma.NewMovingAverage()
+
+func TestSlidingWindow(t *testing.T) {
+	ma := NewSlidingWindow(3)
+	if v := ma.Value(); v != 0 {
+		t.Errorf("empty window: expected 0, got: %v", v)
+	}
+	for i, tc := range []struct{ add, want float64 }{
+		{3, 3},
+		{6, 4.5},
+		{9, 6},
+		{12, 9},
+	} {
+		ma.Add(tc.add)
+		if v := ma.Value(); v != tc.want {
+			t.Errorf("step %d: expected %v, got: %v", i, tc.want, v)
+		}
+	}
+	ma.Set(1)
+	if v := ma.Value(); v != 1 {
+		t.Errorf("after set: expected 1, got: %v", v)
+	}
+}
+
+func TestMedianWindow(t *testing.T) {
+	ma := NewMedianWindow(3)
+	for i, tc := range []struct{ add, want float64 }{
+		{5, 5},
+		{1, 3},
+		{100, 5},
+		{4, 4},
+		{6, 6},
+	} {
+		ma.Add(tc.add)
+		if v := ma.Value(); v != tc.want {
+			t.Errorf("step %d: expected %v, got: %v", i, tc.want, v)
+		}
+	}
+}
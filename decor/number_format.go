@@ -0,0 +1,121 @@
+package decor
+
+import (
+	"strconv"
+	"strings"
+)
+
+// formatNumbers applies DFixedPrec and DThousands to every number of
+// the msg. ANSI escape sequences are left untouched.
+func formatNumbers(msg string, conf, prec int) string {
+	var sb strings.Builder
+	sb.Grow(len(msg))
+	for i := 0; i < len(msg); {
+		if n := escapeLen(msg[i:]); n != 0 {
+			sb.WriteString(msg[i : i+n])
+			i += n
+			continue
+		}
+		n := numberLen(msg[i:])
+		if n == 0 {
+			sb.WriteByte(msg[i])
+			i++
+			continue
+		}
+		num := msg[i : i+n]
+		if (conf & DFixedPrec) != 0 {
+			if f, err := strconv.ParseFloat(num, 64); err == nil {
+				num = strconv.FormatFloat(f, 'f', prec, 64)
+			}
+		}
+		if (conf & DThousands) != 0 {
+			num = groupThousands(num)
+		}
+		sb.WriteString(num)
+		i += n
+	}
+	return sb.String()
+}
+
+// zeroFill returns fill func, which inserts zeros in front of the
+// first number of the message, falling back to provided fill if
+// message has no numbers. Message is measured with m.
+func zeroFill(m Metrics, fallback func(string, int) string) func(string, int) string {
+	return func(s string, w int) string {
+		pad := w - m.StringWidth(s)
+		if pad <= 0 {
+			return s
+		}
+		for i := 0; i < len(s); {
+			if n := escapeLen(s[i:]); n != 0 {
+				i += n
+				continue
+			}
+			if numberLen(s[i:]) != 0 {
+				return s[:i] + strings.Repeat("0", pad) + s[i:]
+			}
+			i++
+		}
+		return fallback(s, w)
+	}
+}
+
+func groupThousands(num string) string {
+	intPart, frac := num, ""
+	if i := strings.IndexByte(num, '.'); i >= 0 {
+		intPart, frac = num[:i], num[i:]
+	}
+	if len(intPart) <= 3 {
+		return num
+	}
+	var sb strings.Builder
+	head := len(intPart) % 3
+	if head != 0 {
+		sb.WriteString(intPart[:head])
+	}
+	for i := head; i < len(intPart); i += 3 {
+		if sb.Len() != 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(intPart[i : i+3])
+	}
+	sb.WriteString(frac)
+	return sb.String()
+}
+
+// numberLen returns length of decimal number at the start of s, or
+// zero if s doesn't start with a digit.
+func numberLen(s string) int {
+	n := digitsLen(s)
+	if n == 0 {
+		return 0
+	}
+	if n < len(s) && s[n] == '.' {
+		if m := digitsLen(s[n+1:]); m != 0 {
+			n += m + 1
+		}
+	}
+	return n
+}
+
+func digitsLen(s string) int {
+	n := 0
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	return n
+}
+
+// escapeLen returns length of CSI escape sequence at the start of s,
+// or zero if s doesn't start with one.
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != '\x1b' || s[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(s)
+}
@@ -0,0 +1,36 @@
+package decor
+
+import "testing"
+
+func TestFormatMsgNumbers(t *testing.T) {
+	cases := map[string]struct {
+		wc       WC
+		msg      string
+		expected string
+	}{
+		"zero pad":       {WC{W: 9, C: DZeroPad}, "12.5MiB", "0012.5MiB"},
+		"zero pad text":  {WC{W: 5, C: DZeroPad}, "foo", "  foo"},
+		"fixed prec":     {WC{C: DFixedPrec, P: 2}, "1.5 / 10", "1.50 / 10.00"},
+		"fixed prec 0":   {WC{C: DFixedPrec}, "2.7KiB", "3KiB"},
+		"thousands":      {WC{C: DThousands}, "1234567 / 12345678.25", "1,234,567 / 12,345,678.25"},
+		"thousands tiny": {WC{C: DThousands}, "999", "999"},
+		"all": {
+			WC{W: 12, C: DZeroPad | DFixedPrec | DThousands, P: 1},
+			"1234.56 b/s",
+			"01,234.6 b/s",
+		},
+		"ansi": {
+			WC{W: 6, C: DZeroPad | DThousands},
+			"\x1b[38;5;1234m1234\x1b[0m",
+			"\x1b[38;5;1234m01,234\x1b[0m",
+		},
+	}
+	for name, tc := range cases {
+		wc := tc.wc
+		wc.Init()
+		got := wc.FormatMsg(tc.msg)
+		if got != tc.expected {
+			t.Errorf("%s: expected: %q, got: %q\n", name, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package decor
+
+import (
+	"strings"
+	"time"
+)
+
+// speedWindow is a period instantaneous speed is measured over. It's
+// long enough to smooth out render jitter.
+const speedWindow = time.Second
+
+// PeakSpeed decorator displays max instantaneous speed observed during
+// bar's lifetime. Instantaneous speed is measured over one second
+// windows of bar's active time, so pauses don't affect it. The last
+// window is measured on completion, however short it is.
+//
+//	`unit` one of [0|UnitKiB|UnitKB] zero for no unit
+//
+//	`format` printf compatible verb for value, like "%f" or "%d"
+//
+//	`wcc` optional WC config
+//
+// format examples:
+//
+//	unit=UnitKiB, format="%.1f"  output: "1.0MiB/s"
+//	unit=UnitKiB, format="% .1f" output: "1.0 MiB/s"
+//
+func PeakSpeed(unit int, format string, wcc ...WC) Decorator {
+	return newSpeedTracker(unit, format, func(t *speedTracker) []float64 {
+		return []float64{t.max}
+	}, wcc...)
+}
+
+// MinSpeed decorator displays min instantaneous speed observed during
+// bar's lifetime, see PeakSpeed.
+func MinSpeed(unit int, format string, wcc ...WC) Decorator {
+	return newSpeedTracker(unit, format, func(t *speedTracker) []float64 {
+		return []float64{t.min}
+	}, wcc...)
+}
+
+// SpeedStats decorator displays min, average and max instantaneous
+// speed in one column, like "1.0 MiB/s / 1.5 MiB/s / 2.0 MiB/s", see
+// PeakSpeed.
+func SpeedStats(unit int, format string, wcc ...WC) Decorator {
+	return newSpeedTracker(unit, format, func(t *speedTracker) []float64 {
+		var avg float64
+		if t.elapsed > 0 {
+			avg = float64(t.current) / t.elapsed.Seconds()
+		}
+		return []float64{t.min, avg, t.max}
+	}, wcc...)
+}
+
+func newSpeedTracker(unit int, format string, values func(*speedTracker) []float64, wcc ...WC) Decorator {
+	if format == "" {
+		format = "%.0f"
+	}
+	return &speedTracker{
+		WC:       initWC(wcc...),
+		producer: chooseSpeedProducer(unit, format, time.Second),
+		values:   values,
+	}
+}
+
+type speedTracker struct {
+	WC
+	producer func(float64) string
+	values   func(*speedTracker) []float64
+	// sampled part of the progress
+	current int64
+	elapsed time.Duration
+	// start of the current window
+	winCurrent int64
+	winElapsed time.Duration
+	min, max   float64
+	sampled    bool
+	completed  bool
+	msg        string
+}
+
+func (d *speedTracker) Decor(s Statistics) string {
+	if !d.completed {
+		d.sample(s)
+		d.completed = s.Completed
+		values := d.values(d)
+		parts := make([]string, len(values))
+		for i, v := range values {
+			parts[i] = d.producer(v)
+		}
+		d.msg = strings.Join(parts, " / ")
+	}
+	return d.FormatMsg(d.msg)
+}
+
+func (d *speedTracker) sample(s Statistics) {
+	dur := s.ActiveElapsed - d.winElapsed
+	// final window is sampled, even if it's not over, so bars which
+	// complete within a window still have their speed measured
+	if dur < speedWindow && (!s.Completed || dur <= 0) {
+		return
+	}
+	n := s.Current - d.winCurrent
+	speed := float64(n) / dur.Seconds()
+	if !d.sampled || speed > d.max {
+		d.max = speed
+	}
+	if !d.sampled || speed < d.min {
+		d.min = speed
+	}
+	d.sampled = true
+	d.current += n
+	d.elapsed += dur
+	d.winCurrent, d.winElapsed = s.Current, s.ActiveElapsed
+}
+
+func (d *speedTracker) Reset() {
+	d.current, d.elapsed = 0, 0
+	d.winCurrent, d.winElapsed = 0, 0
+	d.min, d.max = 0, 0
+	d.sampled, d.completed = false, false
+	d.msg = ""
+}
@@ -0,0 +1,63 @@
+package decor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeakSpeed(t *testing.T) {
+	peak := PeakSpeed(0, "%.0f")
+	min := MinSpeed(0, "%.0f")
+	stats := SpeedStats(0, "%.0f")
+
+	steps := []struct {
+		current int64
+		elapsed time.Duration
+	}{
+		{0, 0},
+		{50, 500 * time.Millisecond}, // window is not over yet
+		{100, time.Second},           // 100/s
+		{400, 2 * time.Second},       // 300/s
+		{450, 3 * time.Second},       // 50/s
+		{460, 3500 * time.Millisecond},
+	}
+	for _, step := range steps {
+		st := Statistics{Current: step.current, ActiveElapsed: step.elapsed}
+		peak.Decor(st)
+		min.Decor(st)
+		stats.Decor(st)
+	}
+
+	st := Statistics{Current: 460, ActiveElapsed: 3500 * time.Millisecond}
+	if got := peak.Decor(st); got != "300" {
+		t.Errorf("peak: expected %q, got: %q", "300", got)
+	}
+	if got := min.Decor(st); got != "50" {
+		t.Errorf("min: expected %q, got: %q", "50", got)
+	}
+	if got := stats.Decor(st); got != "50 / 150 / 300" {
+		t.Errorf("stats: expected %q, got: %q", "50 / 150 / 300", got)
+	}
+
+	peak.(ResetDecorator).Reset()
+	if got := peak.Decor(Statistics{}); got != "0" {
+		t.Errorf("after reset: expected %q, got: %q", "0", got)
+	}
+}
+
+func TestPeakSpeedFinalWindow(t *testing.T) {
+	stats := SpeedStats(0, "%.0f")
+
+	// bar completes within the first window
+	stats.Decor(Statistics{Current: 50, ActiveElapsed: 250 * time.Millisecond})
+	st := Statistics{Current: 100, ActiveElapsed: 500 * time.Millisecond, Completed: true}
+	if got := stats.Decor(st); got != "200 / 200 / 200" {
+		t.Errorf("expected %q, got: %q", "200 / 200 / 200", got)
+	}
+
+	// frozen after completion
+	st.ActiveElapsed = time.Second
+	if got := stats.Decor(st); got != "200 / 200 / 200" {
+		t.Errorf("after completion: expected %q, got: %q", "200 / 200 / 200", got)
+	}
+}
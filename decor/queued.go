@@ -0,0 +1,26 @@
+package decor
+
+// Queued decorator displays message while bar is pending, i.e. has
+// been created with mpb.BarPending option and hasn't started yet, and
+// nothing afterwards. Use width sync, so column keeps its width once
+// the message is gone.
+//
+//	`msg` message to display while bar is pending
+//
+//	`wcc` optional WC config
+//
+func Queued(msg string, wcc ...WC) Decorator {
+	return &queued{initWC(wcc...), msg}
+}
+
+type queued struct {
+	WC
+	msg string
+}
+
+func (d *queued) Decor(s Statistics) string {
+	if s.Pending {
+		return d.FormatMsg(d.msg)
+	}
+	return d.FormatMsg("")
+}
@@ -0,0 +1,43 @@
+package decor
+
+import "sync/atomic"
+
+// SafeText is a decorator, which displays text that can be changed at
+// any time from any goroutine. It may be used as is, or embedded into
+// a custom decorator as a base, which stores message to display.
+//
+// Concurrency contract: Set and Get are safe to call concurrently with
+// each other and with rendering. Decor is called by the render loop
+// only, and displays the most recent text at the time of the call.
+type SafeText struct {
+	WC
+	text atomic.Value
+}
+
+// NewSafeText creates SafeText decorator with initial text.
+//
+//	`text` initial text to display
+//
+//	`wcc` optional WC config
+//
+func NewSafeText(text string, wcc ...WC) *SafeText {
+	d := &SafeText{WC: initWC(wcc...)}
+	d.Set(text)
+	return d
+}
+
+// Set replaces text to display.
+func (d *SafeText) Set(text string) {
+	d.text.Store(text)
+}
+
+// Get returns text to display.
+func (d *SafeText) Get() string {
+	text, _ := d.text.Load().(string)
+	return text
+}
+
+// Decor is implementation of Decorator interface.
+func (d *SafeText) Decor(Statistics) string {
+	return d.FormatMsg(d.Get())
+}
@@ -0,0 +1,34 @@
+package decor
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSafeText(t *testing.T) {
+	var zero SafeText
+	if got := zero.Get(); got != "" {
+		t.Errorf("zero value: expected empty text, got: %q", got)
+	}
+
+	d := NewSafeText("start", WC{W: 6, C: DidentRight})
+	if got := d.Decor(Statistics{}); got != "start " {
+		t.Errorf("expected: %q, got: %q", "start ", got)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				d.Set("busy")
+				d.Get()
+			}
+		}()
+	}
+	wg.Wait()
+	if got := d.Decor(Statistics{}); got != "busy  " {
+		t.Errorf("expected: %q, got: %q", "busy  ", got)
+	}
+}
@@ -0,0 +1,120 @@
+package decor
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/VividCortex/ewma"
+)
+
+// SharedSpeed measures aggregate speed of several bars, for example of
+// all connections to the same host in a download manager. Bars feed it
+// via decorator returned by Feed method, while decorator returned by
+// Speed method displays aggregate speed, typically on a summary bar.
+// Amounts fed by all bars are summed up over sampling interval, and
+// each interval's speed is added to the underlying MovingAverage.
+// SharedSpeed is safe for concurrent use.
+type SharedSpeed struct {
+	mu       sync.Mutex
+	average  MovingAverage
+	interval time.Duration
+	start    time.Time
+	n        int64
+}
+
+// NewSharedSpeed creates SharedSpeed.
+//
+//	`average` MovingAverage implementation, nil for default EWMA
+//
+//	`interval` sampling interval, zero or negative means one second
+//
+func NewSharedSpeed(average MovingAverage, interval time.Duration) *SharedSpeed {
+	if average == nil {
+		average = ewma.NewMovingAverage()
+	}
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return &SharedSpeed{
+		average:  average,
+		interval: interval,
+		start:    time.Now(),
+	}
+}
+
+// Feed returns decorator, which displays nothing, but feeds amounts of
+// bar's EWMA updates, see *Bar.EwmaIncrBy, into s. Add it to every bar,
+// which should be accounted.
+func (s *SharedSpeed) Feed() Decorator {
+	return &sharedSpeedFeed{WC: initWC(), shared: s}
+}
+
+// Speed returns decorator, which displays aggregate speed of s.
+//
+//	`unit` one of [0|UnitKiB|UnitKB] zero for no unit
+//
+//	`format` printf compatible verb for value, like "%f" or "%d"
+//
+//	`wcc` optional WC config
+//
+func (s *SharedSpeed) Speed(unit int, format string, wcc ...WC) Decorator {
+	if format == "" {
+		format = "%.0f"
+	}
+	return &sharedSpeed{
+		WC:       initWC(wcc...),
+		shared:   s,
+		producer: chooseSpeedProducer(unit, format, time.Second),
+	}
+}
+
+// Value returns current aggregate speed in units per second.
+func (s *SharedSpeed) Value() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sample(time.Now())
+	return math.Max(s.average.Value(), 0)
+}
+
+func (s *SharedSpeed) add(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.n += n
+	s.sample(time.Now())
+}
+
+// sample adds speed since previous sample to the average, once
+// sampling interval has passed. Idle time counts as zero speed.
+func (s *SharedSpeed) sample(now time.Time) {
+	elapsed := now.Sub(s.start)
+	if elapsed < s.interval {
+		return
+	}
+	s.average.Add(float64(s.n) / elapsed.Seconds())
+	s.n = 0
+	s.start = now
+}
+
+type sharedSpeedFeed struct {
+	WC
+	shared *SharedSpeed
+}
+
+func (d *sharedSpeedFeed) Decor(Statistics) string {
+	return d.FormatMsg("")
+}
+
+func (d *sharedSpeedFeed) EwmaUpdate(n int64, _ time.Duration) {
+	d.shared.add(n)
+}
+
+type sharedSpeed struct {
+	WC
+	shared   *SharedSpeed
+	producer func(float64) string
+}
+
+func (d *sharedSpeed) Decor(Statistics) string {
+	return d.FormatMsg(d.producer(d.shared.Value()))
+}
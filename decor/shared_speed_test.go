@@ -0,0 +1,42 @@
+package decor
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSharedSpeed(t *testing.T) {
+	shared := NewSharedSpeed(NewSlidingWindow(1), time.Second)
+
+	var wg sync.WaitGroup
+	for _, n := range []int64{100, 200, 300} {
+		feed := shared.Feed().(EwmaDecorator)
+		wg.Add(1)
+		go func(n int64) {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				feed.EwmaUpdate(n/10, time.Millisecond)
+			}
+		}(n)
+	}
+	wg.Wait()
+
+	if got := shared.Value(); got != 0 {
+		t.Errorf("expected no sample before interval has passed, got: %f", got)
+	}
+
+	// pretend two seconds have passed
+	shared.mu.Lock()
+	shared.start = shared.start.Add(-2 * time.Second)
+	shared.mu.Unlock()
+
+	if got := shared.Value(); got < 299 || got > 300 {
+		t.Errorf("expected aggregate speed about 300, got: %f", got)
+	}
+
+	d := shared.Speed(0, "%.0f/s")
+	if got := d.Decor(Statistics{}); got != "300/s" {
+		t.Errorf("expected %q, got: %q", "300/s", got)
+	}
+}
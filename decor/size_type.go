@@ -107,3 +107,70 @@ func (self SizeB1000) Format(st fmt.State, verb rune) {
 	}
 	io.WriteString(st, unit.String())
 }
+
+// sizeFormatter returns size formatter according to unit and its
+// modifiers, or nil if unit is neither UnitKiB nor UnitKB.
+func sizeFormatter(unit int) func(int64) fmt.Formatter {
+	base := unit & unitBaseMask
+	fixed := (unit & unitFixedMask) >> 4
+	threshold := int64(unit >> 8)
+	switch {
+	case base == UnitKiB && fixed == 0 && threshold == 0:
+		return func(v int64) fmt.Formatter { return SizeB1024(v) }
+	case base == UnitKB && fixed == 0 && threshold == 0:
+		return func(v int64) fmt.Formatter { return SizeB1000(v) }
+	case base == UnitKiB:
+		return func(v int64) fmt.Formatter {
+			return scaledSize{v, 1024, fixed, threshold, [...]string{"b", "KiB", "MiB", "GiB", "TiB"}}
+		}
+	case base == UnitKB:
+		return func(v int64) fmt.Formatter {
+			return scaledSize{v, 1000, fixed, threshold, [...]string{"b", "KB", "MB", "GB", "TB"}}
+		}
+	default:
+		return nil
+	}
+}
+
+// scaledSize is like SizeB1024 or SizeB1000, but with either fixed size
+// marker or delayed switching to the next one.
+type scaledSize struct {
+	value     int64
+	base      float64
+	fixed     int
+	threshold int64
+	markers   [5]string
+}
+
+func (self scaledSize) Format(st fmt.State, verb rune) {
+	var prec int
+	switch verb {
+	case 'd':
+	case 's':
+		prec = -1
+	default:
+		if p, ok := st.Precision(); ok {
+			prec = p
+		} else {
+			prec = 6
+		}
+	}
+
+	exp := self.fixed
+	if exp == 0 {
+		threshold := float64(self.threshold)
+		if threshold < 1 {
+			threshold = 1
+		}
+		for exp < len(self.markers)-1 && float64(self.value) >= threshold*math.Pow(self.base, float64(exp+1)) {
+			exp++
+		}
+	}
+
+	io.WriteString(st, strconv.FormatFloat(float64(self.value)/math.Pow(self.base, float64(exp)), 'f', prec, 64))
+
+	if st.Flag(' ') {
+		io.WriteString(st, " ")
+	}
+	io.WriteString(st, self.markers[exp])
+}
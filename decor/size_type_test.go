@@ -100,3 +100,24 @@ func TestB1000(t *testing.T) {
 		})
 	}
 }
+
+func TestUnitModifiers(t *testing.T) {
+	cases := map[string]struct {
+		unit     int
+		value    int64
+		expected string
+	}{
+		"fixed M small":     {UnitKiB | UnitFixedM, 512 * int64(_iKiB), "0.5MiB"},
+		"fixed M big":       {UnitKiB | UnitFixedM, 2 * int64(_iGiB), "2048.0MiB"},
+		"fixed K KB":        {UnitKB | UnitFixedK, 2 * int64(_MB), "2000.0KB"},
+		"threshold below":   {UnitKiB | UnitThreshold(10), 9 * int64(_iMiB), "9216.0KiB"},
+		"threshold reached": {UnitKiB | UnitThreshold(10), 10 * int64(_iMiB), "10.0MiB"},
+		"threshold bytes":   {UnitKB | UnitThreshold(10), 9999, "9999.0b"},
+	}
+	for name, tc := range cases {
+		got := fmt.Sprintf("%.1f", sizeFormatter(tc.unit)(tc.value))
+		if got != tc.expected {
+			t.Fatalf("%s: expected: %s, got: %s\n", name, tc.expected, got)
+		}
+	}
+}
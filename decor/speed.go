@@ -5,8 +5,6 @@ import (
 	"io"
 	"math"
 	"time"
-
-	"github.com/VividCortex/ewma"
 )
 
 // FmtAsSpeed adds "/s" to the end of the input formatter. To be
@@ -15,16 +13,34 @@ import (
 //	fmt.Printf("%.1f", FmtAsSpeed(SizeB1024(2048)))
 //
 func FmtAsSpeed(input fmt.Formatter) fmt.Formatter {
-	return &speedFormatter{input}
+	return FmtAsSpeedPer(input, time.Second)
+}
+
+// FmtAsSpeedPer adds time base suffix like "/s", "/min" or "/h" to the
+// end of the input formatter.
+func FmtAsSpeedPer(input fmt.Formatter, per time.Duration) fmt.Formatter {
+	var suffix string
+	switch per {
+	case time.Second:
+		suffix = "/s"
+	case time.Minute:
+		suffix = "/min"
+	case time.Hour:
+		suffix = "/h"
+	default:
+		suffix = "/" + per.String()
+	}
+	return &speedFormatter{input, suffix}
 }
 
 type speedFormatter struct {
 	fmt.Formatter
+	suffix string
 }
 
 func (self *speedFormatter) Format(st fmt.State, verb rune) {
 	self.Formatter.Format(st, verb)
-	io.WriteString(st, "/s")
+	io.WriteString(st, self.suffix)
 }
 
 // EwmaSpeed exponential-weighted-moving-average based speed decorator.
@@ -32,17 +48,21 @@ func (self *speedFormatter) Format(st fmt.State, verb rune) {
 // iteration's duration and pass it to the
 // *Bar.DecoratorEwmaUpdate(time.Duration) method after each increment.
 func EwmaSpeed(unit int, format string, age float64, wcc ...WC) Decorator {
-	var average ewma.MovingAverage
-	if age == 0 {
-		average = ewma.NewMovingAverage()
-	} else {
-		average = ewma.NewMovingAverage(age)
-	}
-	return MovingAverageSpeed(unit, format, NewThreadSafeMovingAverage(average), wcc...)
+	return EwmaSpeedPer(unit, format, age, time.Second, wcc...)
+}
+
+// EwmaSpeedPer is EwmaSpeed with configurable time base, i.e. it
+// displays speed per `per` duration, like per minute or per hour.
+func EwmaSpeedPer(unit int, format string, age float64, per time.Duration, wcc ...WC) Decorator {
+	newAverage := newEwma(age)
+	d := MovingAverageSpeedPer(unit, format, newAverage(), per, wcc...).(*movingAverageSpeed)
+	d.newAverage = newAverage
+	return d
 }
 
 // MovingAverageSpeed decorator relies on MovingAverage implementation
-// to calculate its average.
+// to calculate its average. Average is fed with speed samples in
+// units per second. It's a wrapper of MovingAverageSpeedPer.
 //
 //	`unit` one of [0|UnitKiB|UnitKB] zero for no unit
 //
@@ -59,42 +79,62 @@ func EwmaSpeed(unit int, format string, age float64, wcc ...WC) Decorator {
 //	unit=UnitKB,  format="%.1f"  output: "1.0MB/s"
 //	unit=UnitKB,  format="% .1f" output: "1.0 MB/s"
 //
-func MovingAverageSpeed(unit int, format string, average ewma.MovingAverage, wcc ...WC) Decorator {
+func MovingAverageSpeed(unit int, format string, average MovingAverage, wcc ...WC) Decorator {
+	return MovingAverageSpeedPer(unit, format, average, time.Second, wcc...)
+}
+
+// MovingAverageSpeedPer is MovingAverageSpeed with configurable time
+// base.
+//
+//	`per` time base, for example time.Minute to display "rows/min"
+//
+// format examples:
+//
+//	unit=UnitKiB, per=time.Minute, format="% .1f" output: "1.0 MiB/min"
+//	unit=0,       per=time.Minute, format="%.0f rows/min" output: "42 rows/min"
+//
+func MovingAverageSpeedPer(unit int, format string, average MovingAverage, per time.Duration, wcc ...WC) Decorator {
 	if format == "" {
 		format = "%.0f"
 	}
 	d := &movingAverageSpeed{
 		WC:       initWC(wcc...),
 		average:  average,
-		producer: chooseSpeedProducer(unit, format),
+		producer: chooseSpeedProducer(unit, format, per),
 	}
 	return d
 }
 
 type movingAverageSpeed struct {
 	WC
-	producer func(float64) string
-	average  ewma.MovingAverage
-	msg      string
+	producer   func(float64) string
+	average    MovingAverage
+	newAverage func() MovingAverage
+	msg        string
 }
 
 func (d *movingAverageSpeed) Decor(s Statistics) string {
 	if !s.Completed {
-		var speed float64
-		if v := d.average.Value(); v > 0 {
-			speed = 1 / v
-		}
-		d.msg = d.producer(speed * 1e9)
+		d.msg = d.producer(math.Max(d.average.Value(), 0))
 	}
 	return d.FormatMsg(d.msg)
 }
 
 func (d *movingAverageSpeed) EwmaUpdate(n int64, dur time.Duration) {
-	durPerByte := float64(dur) / float64(n)
-	if math.IsInf(durPerByte, 0) || math.IsNaN(durPerByte) {
+	if n == 0 {
+		// nothing transferred, e.g. no-op IncrBy call
+		return
+	}
+	perSecond := float64(n) / dur.Seconds()
+	if math.IsInf(perSecond, 0) || math.IsNaN(perSecond) {
 		return
 	}
-	d.average.Add(durPerByte)
+	d.average.Add(perSecond)
+}
+
+func (d *movingAverageSpeed) Reset() {
+	d.average = resetAverage(d.average, d.newAverage)
+	d.msg = ""
 }
 
 // AverageSpeed decorator with dynamic unit measure adjustment. It's
@@ -122,13 +162,21 @@ func AverageSpeed(unit int, format string, wcc ...WC) Decorator {
 //	unit=UnitKB,  format="% .1f" output: "1.0 MB/s"
 //
 func NewAverageSpeed(unit int, format string, startTime time.Time, wcc ...WC) Decorator {
+	return NewAverageSpeedPer(unit, format, startTime, time.Second, wcc...)
+}
+
+// NewAverageSpeedPer is NewAverageSpeed with configurable time base.
+//
+//	`per` time base, for example time.Minute to display "rows/min"
+//
+func NewAverageSpeedPer(unit int, format string, startTime time.Time, per time.Duration, wcc ...WC) Decorator {
 	if format == "" {
 		format = "%.0f"
 	}
 	d := &averageSpeed{
 		WC:        initWC(wcc...),
 		startTime: startTime,
-		producer:  chooseSpeedProducer(unit, format),
+		producer:  chooseSpeedProducer(unit, format, per),
 	}
 	return d
 }
@@ -142,8 +190,8 @@ type averageSpeed struct {
 
 func (d *averageSpeed) Decor(s Statistics) string {
 	if !s.Completed {
-		speed := float64(s.Current) / float64(time.Since(d.startTime))
-		d.msg = d.producer(speed * 1e9)
+		perSecond := float64(s.Current) / time.Since(d.startTime).Seconds()
+		d.msg = d.producer(perSecond)
 	}
 
 	return d.FormatMsg(d.msg)
@@ -153,19 +201,16 @@ func (d *averageSpeed) AverageAdjust(startTime time.Time) {
 	d.startTime = startTime
 }
 
-func chooseSpeedProducer(unit int, format string) func(float64) string {
-	switch unit {
-	case UnitKiB:
-		return func(speed float64) string {
-			return fmt.Sprintf(format, FmtAsSpeed(SizeB1024(math.Round(speed))))
-		}
-	case UnitKB:
-		return func(speed float64) string {
-			return fmt.Sprintf(format, FmtAsSpeed(SizeB1000(math.Round(speed))))
-		}
-	default:
-		return func(speed float64) string {
-			return fmt.Sprintf(format, speed)
+// chooseSpeedProducer returns producer, which expects speed in units
+// per second and formats it according to time base `per`.
+func chooseSpeedProducer(unit int, format string, per time.Duration) func(float64) string {
+	scale := per.Seconds()
+	if size := sizeFormatter(unit); size != nil {
+		return func(perSecond float64) string {
+			return fmt.Sprintf(format, FmtAsSpeedPer(size(int64(math.Round(perSecond*scale))), per))
 		}
 	}
+	return func(perSecond float64) string {
+		return fmt.Sprintf(format, perSecond*scale)
+	}
 }
@@ -260,3 +260,93 @@ func TestSpeedKBDecor(t *testing.T) {
 		})
 	}
 }
+
+func TestMovingAverageSpeedPer(t *testing.T) {
+	cases := []struct {
+		name     string
+		unit     int
+		fmt      string
+		per      time.Duration
+		n        int64
+		dur      time.Duration
+		expected string
+	}{
+		{
+			name:     "UnitKiB:% .1f:per second",
+			unit:     UnitKiB,
+			fmt:      "% .1f",
+			per:      time.Second,
+			n:        2048,
+			dur:      time.Second,
+			expected: "2.0 KiB/s",
+		},
+		{
+			name:     "UnitKiB:% .1f:per minute",
+			unit:     UnitKiB,
+			fmt:      "% .1f",
+			per:      time.Minute,
+			n:        1024,
+			dur:      time.Second,
+			expected: "60.0 KiB/min",
+		},
+		{
+			name:     "NoUnit:%.0f rows/h:per hour",
+			fmt:      "%.0f rows/h",
+			per:      time.Hour,
+			n:        1,
+			dur:      time.Minute,
+			expected: "60 rows/h",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := MovingAverageSpeedPer(tc.unit, tc.fmt, NewMedian(), tc.per)
+			for i := 0; i < 3; i++ {
+				d.(EwmaDecorator).EwmaUpdate(tc.n, tc.dur)
+			}
+			res := d.Decor(Statistics{})
+			if res != tc.expected {
+				t.Fatalf("expected: %q, got: %q\n", tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestMovingAverageSpeedReset(t *testing.T) {
+	cases := []struct {
+		name string
+		d    Decorator
+	}{
+		{"ewma", EwmaSpeed(0, "%.0f", 30)},
+		{"sliding window", MovingAverageSpeed(0, "%.0f", NewSlidingWindow(30))},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				tc.d.(EwmaDecorator).EwmaUpdate(100, time.Second)
+			}
+			tc.d.(ResetDecorator).Reset()
+			// samples before reset must not bias new average
+			for i := 0; i < 10; i++ {
+				tc.d.(EwmaDecorator).EwmaUpdate(10, time.Second)
+			}
+			if res := tc.d.Decor(Statistics{}); res != "10" {
+				t.Errorf("expected: %q, got: %q\n", "10", res)
+			}
+		})
+	}
+}
+
+func TestMovingAverageSpeedZeroUpdate(t *testing.T) {
+	d := MovingAverageSpeed(0, "%.0f", NewSlidingWindow(3))
+	for i := 0; i < 3; i++ {
+		d.(EwmaDecorator).EwmaUpdate(10, time.Second)
+	}
+	// updates with zero amount must not pull average down
+	for i := 0; i < 3; i++ {
+		d.(EwmaDecorator).EwmaUpdate(0, time.Second)
+	}
+	if res := d.Decor(Statistics{}); res != "10" {
+		t.Errorf("expected: %q, got: %q\n", "10", res)
+	}
+}
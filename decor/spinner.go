@@ -2,7 +2,10 @@ package decor
 
 var defaultSpinnerStyle = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 
-// Spinner returns spinner decorator.
+var asciiSpinnerStyle = []string{"-", "\\", "|", "/"}
+
+// Spinner returns spinner decorator. Frames, which are not pure ASCII,
+// are replaced with ASCII ones on terminals without unicode support.
 //
 //	`frames` spinner frames, if nil or len==0, default is used
 //
@@ -11,11 +14,28 @@ func Spinner(frames []string, wcc ...WC) Decorator {
 	if len(frames) == 0 {
 		frames = defaultSpinnerStyle
 	}
-	var count uint
-	f := func(s Statistics) string {
-		frame := frames[count%uint(len(frames))]
-		count++
-		return frame
+	return &spinner{WC: initWC(wcc...), frames: frames}
+}
+
+type spinner struct {
+	WC
+	frames []string
+	count  uint
+}
+
+func (d *spinner) Decor(Statistics) string {
+	frame := d.frames[d.count%uint(len(d.frames))]
+	d.count++
+	return d.FormatMsg(frame)
+}
+
+func (d *spinner) AdaptASCII() {
+	for _, f := range d.frames {
+		for i := 0; i < len(f); i++ {
+			if f[i] >= 0x80 {
+				d.frames = asciiSpinnerStyle
+				return
+			}
+		}
 	}
-	return Any(f, wcc...)
 }
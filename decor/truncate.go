@@ -0,0 +1,98 @@
+package decor
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/acarl005/stripansi"
+)
+
+// TruncateString truncates s to fit into w cells, appending tail if
+// truncation took place. Width is measured in terminal cells, so wide
+// runes are accounted properly. Combining characters are never
+// separated from their base rune. CSI escape sequences don't occupy
+// any cell and are kept, including ones past truncation point, which
+// follow tail, so trailing color reset isn't lost. Unterminated bidi
+// embeddings or isolates are closed before tail, so tail is always
+// rendered in the base direction.
+//
+//	`s` string to truncate
+//
+//	`w` max width in cells, including tail
+//
+//	`tail` string to append on truncation, typically "…"
+//
+func TruncateString(s string, w int, tail string) string {
+	return Metrics{}.TruncateString(s, w, tail)
+}
+
+// TruncateString is TruncateString, which measures width with m.
+func (m Metrics) TruncateString(s string, w int, tail string) string {
+	if m.StringWidth(stripansi.Strip(s)) <= w {
+		return s
+	}
+	tw := m.StringWidth(tail)
+	if tw > w {
+		tail, tw = "", 0
+	}
+	var sb, esc strings.Builder
+	var bidi []rune
+	var truncated, joined bool
+	width := 0
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n != 0 {
+			if truncated {
+				// sequences past truncation point, like color reset,
+				// still apply
+				esc.WriteString(s[i : i+n])
+			} else {
+				sb.WriteString(s[i : i+n])
+			}
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		// glyph joined to previous one takes no cells, see StringWidth
+		wasJoined := joined
+		joined = r == zwj
+		if !truncated && !wasJoined && !isZeroWidth(r) {
+			rw := m.runeWidth(r)
+			if width+rw > w-tw {
+				truncated = true
+			}
+			width += rw
+		}
+		if truncated {
+			i += size
+			continue
+		}
+		switch {
+		case r >= '\u202a' && r <= '\u202e' && r != '\u202c', r >= '\u2066' && r <= '\u2068':
+			bidi = append(bidi, r)
+		case r == '\u202c', r == '\u2069':
+			if len(bidi) != 0 {
+				bidi = bidi[:len(bidi)-1]
+			}
+		}
+		sb.WriteString(s[i : i+size])
+		i += size
+	}
+	for i := len(bidi) - 1; i >= 0; i-- {
+		if bidi[i] >= '\u2066' {
+			sb.WriteRune('\u2069')
+		} else {
+			sb.WriteRune('\u202c')
+		}
+	}
+	sb.WriteString(tail)
+	sb.WriteString(esc.String())
+	return sb.String()
+}
+
+// isZeroWidth reports whether r attaches to preceding rune or is an
+// invisible formatting character.
+func isZeroWidth(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) ||
+		(r >= '\ufe00' && r <= '\ufe0f')
+}
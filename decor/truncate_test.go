@@ -0,0 +1,40 @@
+package decor
+
+import "testing"
+
+func TestTruncateString(t *testing.T) {
+	cases := map[string]struct {
+		s        string
+		w        int
+		expected string
+	}{
+		"fits":      {"foo", 3, "foo"},
+		"ascii":     {"foobar", 4, "foo…"},
+		"wide":      {"日本語テキスト", 7, "日本語…"},
+		"combining": {"e\u0301e\u0301e\u0301e\u0301", 3, "e\u0301e\u0301…"},
+		"ansi":      {"\x1b[31mfoobar\x1b[0m", 4, "\x1b[31mfoo…\x1b[0m"},
+		"ansi mid":  {"\x1b[31mfoo\x1b[0mbar\x1b[1m!", 4, "\x1b[31mfoo\x1b[0m…\x1b[1m"},
+		"ansi fits": {"\x1b[31mfoo\x1b[0m", 3, "\x1b[31mfoo\x1b[0m"},
+		"isolate":   {"a\u2067שלום\u2069b", 4, "a\u2067של\u2069…"},
+		"embedding": {"\u202bשלום\u202c", 3, "\u202bשל\u202c…"},
+		"tail wide": {"foobar", 0, ""},
+		"zwj":       {"\U0001F468\u200d\U0001F469abc", 4, "\U0001F468\u200d\U0001F469a…"},
+	}
+	for name, tc := range cases {
+		got := TruncateString(tc.s, tc.w, "…")
+		if got != tc.expected {
+			t.Errorf("%s: expected: %q, got: %q\n", name, tc.expected, got)
+		}
+	}
+}
+
+func TestNameTruncate(t *testing.T) {
+	d := Name("very long name", WC{W: 8, C: DTruncate | DidentRight})
+	if got := d.Decor(Statistics{}); got != "very lo…" {
+		t.Errorf("expected: %q, got: %q\n", "very lo…", got)
+	}
+	d = Name("short", WC{W: 8, C: DTruncate | DidentRight})
+	if got := d.Decor(Statistics{}); got != "short   " {
+		t.Errorf("expected: %q, got: %q\n", "short   ", got)
+	}
+}
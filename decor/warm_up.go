@@ -0,0 +1,93 @@
+package decor
+
+import "time"
+
+// WarmUp returns decorator, which wraps provided decorator, with sole
+// purpose to display placeholder, like "--.- MiB/s", instead of
+// misleading values during warm up period. Warm up is over once both
+// conditions are met. Intended to wrap EWMA based decorators, like
+// EwmaSpeed or EwmaETA, as samples are counted on EwmaUpdate calls.
+//
+//	`decorator` Decorator to wrap
+//
+//	`minSamples` min number of ewma samples, zero to ignore
+//
+//	`minElapsed` min bar's elapsed time, zero to ignore
+//
+//	`placeholder` message to display during warm up
+//
+func WarmUp(decorator Decorator, minSamples int, minElapsed time.Duration, placeholder string) Decorator {
+	return &warmUpWrapper{
+		Decorator:   decorator,
+		minSamples:  minSamples,
+		minElapsed:  minElapsed,
+		placeholder: placeholder,
+	}
+}
+
+// warmUpWrapper deliberately doesn't implement Wrapper interface, so
+// it's subscribed to ewma updates itself and forwards them along with
+// other notifications to the wrapped decorator.
+type warmUpWrapper struct {
+	Decorator
+	minSamples  int
+	minElapsed  time.Duration
+	placeholder string
+	samples     int
+	warm        bool
+}
+
+func (d *warmUpWrapper) Decor(s Statistics) string {
+	if !d.warm {
+		d.warm = d.samples >= d.minSamples && s.Elapsed >= d.minElapsed
+	}
+	if !d.warm && !s.Completed {
+		wc := d.GetConf()
+		return wc.FormatMsg(d.placeholder)
+	}
+	return d.Decorator.Decor(s)
+}
+
+func (d *warmUpWrapper) EwmaUpdate(n int64, dur time.Duration) {
+	d.samples++
+	if ed, ok := d.base().(EwmaDecorator); ok {
+		ed.EwmaUpdate(n, dur)
+	}
+}
+
+func (d *warmUpWrapper) AverageAdjust(startTime time.Time) {
+	if ad, ok := d.base().(AverageDecorator); ok {
+		ad.AverageAdjust(startTime)
+	}
+}
+
+func (d *warmUpWrapper) Reset() {
+	d.samples = 0
+	d.warm = false
+	if rd, ok := d.base().(ResetDecorator); ok {
+		rd.Reset()
+	}
+}
+
+func (d *warmUpWrapper) AdaptASCII() {
+	if aa, ok := d.base().(ASCIIAdapter); ok {
+		aa.AdaptASCII()
+	}
+}
+
+func (d *warmUpWrapper) Shutdown() {
+	if sl, ok := d.base().(ShutdownListener); ok {
+		sl.Shutdown()
+	}
+}
+
+func (d *warmUpWrapper) base() Decorator {
+	base := d.Decorator
+	for {
+		w, ok := base.(Wrapper)
+		if !ok {
+			return base
+		}
+		base = w.Base()
+	}
+}
@@ -0,0 +1,41 @@
+package decor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWarmUp(t *testing.T) {
+	placeholder := "--.- KiB/s"
+	d := WarmUp(EwmaSpeed(UnitKiB, "% .1f", 30), 3, time.Second, placeholder)
+
+	for i := 0; i < 3; i++ {
+		if got := d.Decor(Statistics{Elapsed: 2 * time.Second}); got != placeholder {
+			t.Fatalf("sample %d: expected placeholder, got: %q", i, got)
+		}
+		d.(EwmaDecorator).EwmaUpdate(1024, time.Second)
+	}
+	if got := d.Decor(Statistics{}); got != placeholder {
+		t.Errorf("min elapsed not reached: expected placeholder, got: %q", got)
+	}
+	if got := d.Decor(Statistics{Elapsed: time.Second}); got != "1.0 KiB/s" {
+		t.Errorf("expected: %q, got: %q", "1.0 KiB/s", got)
+	}
+
+	d.(ResetDecorator).Reset()
+	if got := d.Decor(Statistics{Elapsed: time.Second}); got != placeholder {
+		t.Errorf("after reset: expected placeholder, got: %q", got)
+	}
+}
+
+func TestWarmUpAdaptASCII(t *testing.T) {
+	d := WarmUp(Spinner(nil), 0, 0, "")
+	aa, ok := d.(ASCIIAdapter)
+	if !ok {
+		t.Fatal("expected WarmUp to forward ASCII adaptation")
+	}
+	aa.AdaptASCII()
+	if got := d.Decor(Statistics{}); got != asciiSpinnerStyle[0] {
+		t.Errorf("expected: %q, got: %q", asciiSpinnerStyle[0], got)
+	}
+}
@@ -0,0 +1,94 @@
+package decor
+
+import (
+	"fmt"
+	"sync"
+)
+
+// WeightedSet aggregates progress of several bars, taking into account
+// weight of each one. It's useful for an overall bar, whose children
+// have wildly different totals, so an overall percentage reflects
+// actual work remaining, rather than bar count.
+type WeightedSet struct {
+	mu    sync.Mutex
+	parts []*weightedPart
+}
+
+type weightedPart struct {
+	weight   float64
+	progress float64
+}
+
+// NewWeightedSet creates an empty WeightedSet.
+func NewWeightedSet() *WeightedSet {
+	return new(WeightedSet)
+}
+
+// Track returns zero width decorator, which feeds progress of a bar
+// it's added to into the set. Weight is a share of the bar in overall
+// work, for example bytes to download or number of files.
+//
+//	`weight` bar's weight, non positive weight is treated as 1
+//
+func (ws *WeightedSet) Track(weight float64) Decorator {
+	if weight <= 0 {
+		weight = 1
+	}
+	part := &weightedPart{weight: weight}
+	ws.mu.Lock()
+	ws.parts = append(ws.parts, part)
+	ws.mu.Unlock()
+	fn := func(s Statistics) string {
+		var progress float64
+		switch {
+		case s.Completed:
+			progress = 1
+		case s.Total > 0:
+			progress = float64(s.Current) / float64(s.Total)
+		}
+		if progress > 1 {
+			progress = 1
+		}
+		ws.mu.Lock()
+		part.progress = progress
+		ws.mu.Unlock()
+		return ""
+	}
+	return Any(fn)
+}
+
+// Progress returns weighted average progress of all tracked bars, in
+// [0, 1] range.
+func (ws *WeightedSet) Progress() float64 {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var sum, weights float64
+	for _, p := range ws.parts {
+		sum += p.weight * p.progress
+		weights += p.weight
+	}
+	if weights == 0 {
+		return 0
+	}
+	return sum / weights
+}
+
+// WeightedPercentage returns percentage decorator, which displays
+// weighted average progress of bars tracked by ws, instead of the
+// bar's own progress.
+//
+//	`ws` set of tracked bars
+//
+//	`format` printf compatible verb, see NewPercentage for examples
+//
+//	`wcc` optional WC config
+//
+func WeightedPercentage(ws *WeightedSet, format string, wcc ...WC) Decorator {
+	if format == "" {
+		format = "% d"
+	}
+	fn := func(Statistics) string {
+		return fmt.Sprintf(format, percentageType(ws.Progress()*100))
+	}
+	return Any(fn, wcc...)
+}
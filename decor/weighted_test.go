@@ -0,0 +1,18 @@
+package decor
+
+import "testing"
+
+func TestWeightedPercentage(t *testing.T) {
+	ws := NewWeightedSet()
+	big := ws.Track(900)
+	small := ws.Track(100)
+	d := WeightedPercentage(ws, "%d")
+
+	// big one is half done, small one is complete
+	big.Decor(Statistics{Total: 1000, Current: 500})
+	small.Decor(Statistics{Total: 2, Current: 2, Completed: true})
+
+	if got, want := d.Decor(Statistics{}), "55%"; got != want {
+		t.Errorf("want: %q, got: %q\n", want, got)
+	}
+}
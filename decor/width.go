@@ -0,0 +1,130 @@
+package decor
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+const zwj = '\u200d'
+
+// DefaultTabStop is tab stop, tabs in decorator text are expanded to,
+// unless Metrics.TabStop is set.
+const DefaultTabStop = 8
+
+// Metrics is a set of rules, display width of decorator text is
+// measured with. Zero value measures with go-runewidth and expands tabs
+// to DefaultTabStop. Metrics is set per decorator with WC.M field, or
+// for all decorators of a container with mpb.WithTextMetrics option.
+type Metrics struct {
+	// RuneWidth is table of display widths, which take precedence over
+	// widths reported by go-runewidth, for exotic glyphs rendered
+	// differently by user's terminal, for example emoji with variation
+	// selectors.
+	RuneWidth map[rune]int
+	// TabStop is tab stop, tabs in decorator text are expanded to.
+	// Zero means DefaultTabStop, negative value disables expansion.
+	TabStop int
+}
+
+// IsZero reports whether m is zero value, i.e. default Metrics.
+func (m Metrics) IsZero() bool {
+	return len(m.RuneWidth) == 0 && m.TabStop == 0
+}
+
+// StringWidth returns display width of s, honoring rune width
+// overrides. Combining marks, format characters and variation
+// selectors take no cells, so does glyph joined to previous one by
+// zero width joiner. ANSI escape sequences are not stripped.
+func (m Metrics) StringWidth(s string) int {
+	var width int
+	var joined bool
+	for _, r := range s {
+		switch {
+		case r == zwj:
+			joined = true
+		case joined:
+			joined = false
+		default:
+			width += m.runeWidth(r)
+		}
+	}
+	return width
+}
+
+// ExpandTabs replaces tabs in s with spaces, up to the next tab stop.
+// Columns are counted from the start of s.
+func (m Metrics) ExpandTabs(s string) string {
+	tabStop := m.TabStop
+	if tabStop == 0 {
+		tabStop = DefaultTabStop
+	}
+	if tabStop < 0 || !strings.ContainsRune(s, '\t') {
+		return s
+	}
+	var sb strings.Builder
+	var col int
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n != 0 {
+			sb.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		j := strings.IndexAny(s[i:], "\t\x1b")
+		if j < 0 {
+			j = len(s) - i
+		}
+		if j != 0 {
+			sb.WriteString(s[i : i+j])
+			col += m.StringWidth(s[i : i+j])
+			i += j
+			continue
+		}
+		if s[i] == '\t' {
+			n := tabStop - col%tabStop
+			sb.WriteString(strings.Repeat(" ", n))
+			col += n
+		} else {
+			// lone escape byte, not a sequence
+			sb.WriteByte(s[i])
+		}
+		i++
+	}
+	return sb.String()
+}
+
+// StringWidth is Metrics.StringWidth with default Metrics.
+func StringWidth(s string) int {
+	return Metrics{}.StringWidth(s)
+}
+
+// ExpandTabs is Metrics.ExpandTabs with default Metrics.
+func ExpandTabs(s string) string {
+	return Metrics{}.ExpandTabs(s)
+}
+
+func (m Metrics) runeWidth(r rune) int {
+	if w, ok := m.RuneWidth[r]; ok {
+		return w
+	}
+	if isZeroWidth(r) {
+		return 0
+	}
+	return runewidth.RuneWidth(r)
+}
+
+// fillLeft and fillRight are runewidth.FillLeft and runewidth.FillRight
+// counterparts, which measure s with m.
+func (m Metrics) fillLeft(s string, w int) string {
+	if n := w - m.StringWidth(s); n > 0 {
+		return strings.Repeat(" ", n) + s
+	}
+	return s
+}
+
+func (m Metrics) fillRight(s string, w int) string {
+	if n := w - m.StringWidth(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
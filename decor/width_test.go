@@ -0,0 +1,52 @@
+package decor
+
+import "testing"
+
+func TestStringWidth(t *testing.T) {
+	family := "\U0001F468\u200d\U0001F469\u200d\U0001F467"
+	if got := StringWidth(family); got != 2 {
+		t.Errorf("zwj sequence: want width 2, got %d", got)
+	}
+
+	sun := "\u2600\ufe0f"
+	if got := StringWidth(sun); got != 1 {
+		t.Errorf("without override: want width 1, got %d", got)
+	}
+	m := Metrics{RuneWidth: map[rune]int{'\u2600': 2}}
+	if got := m.StringWidth(sun); got != 2 {
+		t.Errorf("with override: want width 2, got %d", got)
+	}
+	if got := m.fillLeft(sun, 4); got != "  "+sun {
+		t.Errorf("fillLeft: want %q, got %q", "  "+sun, got)
+	}
+
+	d := Name(sun, WC{W: 4, M: m})
+	if got := d.Decor(Statistics{}); got != "  "+sun {
+		t.Errorf("decorator: want %q, got %q", "  "+sun, got)
+	}
+}
+
+func TestExpandTabs(t *testing.T) {
+	tests := []struct {
+		tabStop int
+		in      string
+		want    string
+	}{
+		{0, "a\tb", "a       b"},
+		{4, "ab\tc\td", "ab  c   d"},
+		{4, "\x1b[31mab\x1b[0m\tc", "\x1b[31mab\x1b[0m  c"},
+		{4, "日\tx", "日  x"},
+		{-1, "a\tb", "a\tb"},
+	}
+	for _, tc := range tests {
+		m := Metrics{TabStop: tc.tabStop}
+		if got := m.ExpandTabs(tc.in); got != tc.want {
+			t.Errorf("tab stop %d: want %q, got %q", tc.tabStop, tc.want, got)
+		}
+	}
+
+	d := Name("a\tb", WC{W: 6, M: Metrics{TabStop: 4}})
+	if got := d.Decor(Statistics{}); got != " a   b" {
+		t.Errorf("decorator: want %q, got %q", " a   b", got)
+	}
+}
@@ -2,8 +2,13 @@ package mpb
 
 import (
 	"bytes"
+	"strings"
 	"testing"
+	"time"
 	"unicode/utf8"
+
+	"github.com/acarl005/stripansi"
+	"github.com/vbauerster/mpb/v5/decor"
 )
 
 func TestDraw(t *testing.T) {
@@ -379,3 +384,264 @@ func newTestState(style string, reverse bool) *bState {
 	}
 	return s
 }
+
+func TestDrawProgressive(t *testing.T) {
+	testSuite := []struct {
+		name    string
+		total   int64
+		current int64
+		reverse bool
+		want    string
+	}{
+		{
+			name:    "t,c{80,0}",
+			total:   80,
+			current: 0,
+			want:    "⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀",
+		},
+		{
+			name:    "t,c{80,13}",
+			total:   80,
+			current: 13,
+			want:    "⣿⣇⠀⠀⠀⠀⠀⠀⠀⠀",
+		},
+		{
+			name:    "t,c{80,13}reverse",
+			total:   80,
+			current: 13,
+			reverse: true,
+			want:    "⠀⠀⠀⠀⠀⠀⠀⠀⣇⣿",
+		},
+		{
+			name:    "t,c{80,80}",
+			total:   80,
+			current: 80,
+			want:    "⣿⣿⣿⣿⣿⣿⣿⣿⣿⣿",
+		},
+	}
+
+	var tmpBuf bytes.Buffer
+	for _, tc := range testSuite {
+		s := newTestState("", false)
+		s.filler = NewProgressiveBarFiller(BrailleBarStyle, tc.reverse)
+		s.total = tc.total
+		s.current = tc.current
+		s.trimSpace = true
+		tmpBuf.Reset()
+		tmpBuf.ReadFrom(s.draw(newStatistics(10, s)))
+		by := tmpBuf.Bytes()
+
+		got := string(by[:len(by)-1])
+		if got != tc.want {
+			t.Errorf("%q want: %q, got: %q\n", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestDrawGradient(t *testing.T) {
+	s := newTestState("", false)
+	s.filler.(*barFiller).SetGradient(RGB{255, 0, 0}, RGB{0, 255, 0}, ColorTrue)
+	s.total = 100
+	s.current = 50
+	s.trimSpace = true
+
+	var buf bytes.Buffer
+	buf.ReadFrom(s.draw(newStatistics(12, s)))
+	got := buf.String()
+
+	want := "[====>-----]\n"
+	if plain := stripansi.Strip(got); plain != want {
+		t.Errorf("want: %q, got: %q\n", want, plain)
+	}
+	if !strings.HasPrefix(got, "[\x1b[38;2;255;0;0m=\x1b[0m") {
+		t.Errorf("expected first cell colored red, got: %q\n", got)
+	}
+}
+
+func TestDrawOverlay(t *testing.T) {
+	testSuite := []struct {
+		name    string
+		reverse bool
+		invert  bool
+		want    string
+	}{
+		{
+			name: "plain",
+			want: "[===50%----]\n",
+		},
+		{
+			name:    "reverse",
+			reverse: true,
+			want:    "[---50%====]\n",
+		},
+		{
+			name:   "invert",
+			invert: true,
+			want:   "[===\x1b[7m5\x1b[27m\x1b[7m0\x1b[27m%----]\n",
+		},
+	}
+
+	for _, tc := range testSuite {
+		s := newTestState("", tc.reverse)
+		BarOverlay(nil)(s)
+		s.filler.(*barFiller).invert = tc.invert
+		s.total = 100
+		s.current = 50
+		s.trimSpace = true
+
+		var buf bytes.Buffer
+		buf.ReadFrom(s.draw(newStatistics(12, s)))
+		if got := buf.String(); got != tc.want {
+			t.Errorf("%q want: %q, got: %q\n", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestAdaptTerminalASCII(t *testing.T) {
+	s := newTestState("╢▌▌░╟", false)
+	s.total = 100
+	s.current = 50
+	s.trimSpace = true
+	s.term = Terminal{Unicode: false}
+	s.adaptTerminal()
+
+	var buf bytes.Buffer
+	buf.ReadFrom(s.draw(newStatistics(12, s)))
+
+	want := "[====>-----]\n"
+	if got := buf.String(); got != want {
+		t.Errorf("want: %q, got: %q\n", want, got)
+	}
+}
+
+func TestDrawNopFiller(t *testing.T) {
+	s := newTestState("", false)
+	s.filler = BarFillerNop()
+	s.total = 100
+	s.current = 42
+	s.trimSpace = true
+	s.pDecorators = []decor.Decorator{decor.Name("worker: ")}
+	s.aDecorators = []decor.Decorator{decor.CountersNoUnit("%d/%d")}
+
+	var buf bytes.Buffer
+	buf.ReadFrom(s.draw(newStatistics(80, s)))
+
+	want := "worker: 42/100\n"
+	if got := buf.String(); got != want {
+		t.Errorf("want: %q, got: %q\n", want, got)
+	}
+}
+
+func TestDrawCompact(t *testing.T) {
+	testSuite := []struct {
+		termWidth int
+		want      string
+	}{
+		{40, "file 50/100 [==========>----------] 50 %"},
+		{30, "file 50/100 [=====>-----] 50 %"},
+		{20, "file [===>----] 50 %"},
+		{8, "file [=]"},
+	}
+
+	for _, tc := range testSuite {
+		s := newTestState("", false)
+		s.total = 100
+		s.current = 50
+		s.trimSpace = true
+		s.compactMin = 10
+		s.pDecorators = []decor.Decorator{
+			decor.Name("file "),
+			decor.DropPriority(decor.CountersNoUnit("%d/%d "), 2),
+		}
+		s.aDecorators = []decor.Decorator{
+			decor.DropPriority(decor.Percentage(decor.WC{W: 5}), 1),
+		}
+
+		var buf bytes.Buffer
+		buf.ReadFrom(s.draw(newStatistics(tc.termWidth, s)))
+		by := buf.Bytes()
+
+		got := string(by[:len(by)-1])
+		if got != tc.want {
+			t.Errorf("termWidth %d: want: %q, got: %q\n", tc.termWidth, tc.want, got)
+		}
+	}
+}
+
+func TestDrawSeparator(t *testing.T) {
+	testSuite := []struct {
+		compactMin int
+		termWidth  int
+		want       string
+	}{
+		{0, 40, "file|50/100[=========>----------]50 %|ok"},
+		{10, 40, "file|50/100[=========>----------]50 %|ok"},
+		{10, 25, "file[=====>------]50 %|ok"},
+		{10, 20, "file[=====>------]ok"},
+	}
+
+	for _, tc := range testSuite {
+		s := newTestState("", false)
+		s.total = 100
+		s.current = 50
+		s.trimSpace = true
+		s.separator = "|"
+		s.compactMin = tc.compactMin
+		// decorators rendering nothing get no separator
+		s.pDecorators = []decor.Decorator{
+			decor.Name(""),
+			decor.Name("file"),
+			decor.DropPriority(decor.CountersNoUnit("%d/%d"), 2),
+		}
+		s.aDecorators = []decor.Decorator{
+			decor.DropPriority(decor.Percentage(decor.WC{W: 4}), 1),
+			decor.Name(""),
+			decor.Name("ok"),
+			decor.Name(""),
+		}
+
+		var buf bytes.Buffer
+		buf.ReadFrom(s.draw(newStatistics(tc.termWidth, s)))
+		by := buf.Bytes()
+
+		got := string(by[:len(by)-1])
+		if got != tc.want {
+			t.Errorf("compactMin %d termWidth %d: want: %q, got: %q\n",
+				tc.compactMin, tc.termWidth, tc.want, got)
+		}
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	sl := newSparkline(4, time.Second, true)
+	start := time.Now()
+	sl.roll(start)
+	sl.add(10, start.Add(500*time.Millisecond))
+	sl.add(30, start.Add(1500*time.Millisecond))
+	sl.add(10, start.Add(2500*time.Millisecond))
+	sl.roll(start.Add(3500 * time.Millisecond))
+
+	var buf bytes.Buffer
+	sl.Fill(&buf, 0, decor.Statistics{Completed: true})
+
+	// three samples so far: 10 over 1.5s, 30 and 10 units per second
+	want := " ▃█▃"
+	if got := buf.String(); got != want {
+		t.Errorf("want: %q, got: %q\n", want, got)
+	}
+}
+
+func TestDimPending(t *testing.T) {
+	s := newTestState("", false)
+	s.pending = true
+	s.term = Terminal{IsTTY: true, VT: true}
+
+	line := "\x1b[31mred\x1b[0m \x1b[1;32mbold\x1b[22m \x1b[m\x1b[38;5;200mx\n"
+	var buf bytes.Buffer
+	buf.ReadFrom(s.dimPending(strings.NewReader(line)))
+
+	want := "\x1b[2m\x1b[31mred\x1b[0m\x1b[2m \x1b[1;32mbold\x1b[22m\x1b[2m \x1b[m\x1b[2m\x1b[38;5;200mx\x1b[22m\n"
+	if got := buf.String(); got != want {
+		t.Errorf("want: %q, got: %q", want, got)
+	}
+}
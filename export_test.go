@@ -1,4 +1,18 @@
 package mpb
 
 // make syncWidth func public in test
-var SyncWidth = syncWidth
+func SyncWidth(matrix map[int][]chan int) {
+	cells := make(map[int][]*syncCell, len(matrix))
+	for i, column := range matrix {
+		for _, ch := range column {
+			cells[i] = append(cells[i], &syncCell{bar: new(Bar), ch: ch})
+		}
+	}
+	syncWidth(cells, nil)
+}
+
+// make abortIncomplete method public in test, WaitWithTimeout calls it
+// once deadline has passed
+func (p *Progress) AbortIncomplete() int {
+	return len(p.abortIncomplete())
+}
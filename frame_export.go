@@ -0,0 +1,301 @@
+package mpb
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/vbauerster/mpb/v5/decor"
+)
+
+const (
+	svgFontSize   = 14
+	svgCellWidth  = 8.4
+	svgLineHeight = 18
+	svgPadding    = 8
+)
+
+// xterm's default colors of the basic 16-color palette.
+var basicPalette = [16]RGB{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// sgrStyle is a subset of SGR attributes, which survives export.
+type sgrStyle struct {
+	fg, bg  *RGB
+	bold    bool
+	dim     bool
+	inverse bool
+}
+
+// segment is a run of text of the same style, col is its starting
+// column within the line.
+type segment struct {
+	text  string
+	col   int
+	style sgrStyle
+}
+
+// WriteHTML writes the most recently rendered frame into w as HTML
+// snippet, a <pre> element with a <span> per styled run of text, so
+// CI systems and web dashboards can embed state of progress. Colors
+// and bold, dim and inverse attributes are preserved. Being called
+// after *Progress.Wait, it writes the final frame.
+func (p *Progress) WriteHTML(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(`<pre class="mpb">`)
+	for i, line := range p.frameLines() {
+		if i != 0 {
+			bw.WriteByte('\n')
+		}
+		for _, seg := range parseSGR(string(line), p.metrics) {
+			if css := seg.style.css("color", "background-color"); css != "" {
+				fmt.Fprintf(bw, `<span style="%s">%s</span>`, css, html.EscapeString(seg.text))
+			} else {
+				bw.WriteString(html.EscapeString(seg.text))
+			}
+		}
+	}
+	bw.WriteString("</pre>\n")
+	return bw.Flush()
+}
+
+// WriteSVG writes the most recently rendered frame into w as SVG
+// image, with monospace text positioned by terminal columns. Being
+// called after *Progress.Wait, it writes the final frame.
+func (p *Progress) WriteSVG(w io.Writer) error {
+	lines := p.frameLines()
+	parsed := make([][]segment, len(lines))
+	var cols int
+	for i, line := range lines {
+		parsed[i] = parseSGR(string(line), p.metrics)
+		if n := len(parsed[i]); n != 0 {
+			last := parsed[i][n-1]
+			if c := last.col + p.metrics.StringWidth(last.text); c > cols {
+				cols = c
+			}
+		}
+	}
+	width := float64(cols)*svgCellWidth + 2*svgPadding
+	height := len(lines)*svgLineHeight + 2*svgPadding
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%d" font-family="monospace" font-size="%d">`+"\n",
+		width, height, svgFontSize)
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", basicPalette[0].hex())
+	for i, segs := range parsed {
+		y := svgPadding + (i+1)*svgLineHeight - (svgLineHeight-svgFontSize)
+		fmt.Fprintf(bw, `<text y="%d" xml:space="preserve" fill="%s">`, y, basicPalette[7].hex())
+		for _, seg := range segs {
+			x := svgPadding + float64(seg.col)*svgCellWidth
+			fmt.Fprintf(bw, `<tspan x="%.1f"`, x)
+			if css := seg.style.css("fill", ""); css != "" {
+				fmt.Fprintf(bw, ` style="%s"`, css)
+			}
+			fmt.Fprintf(bw, `>%s</tspan>`, html.EscapeString(seg.text))
+		}
+		bw.WriteString("</text>\n")
+	}
+	bw.WriteString("</svg>\n")
+	return bw.Flush()
+}
+
+// frameLines returns copy of the most recently rendered frame.
+func (p *Progress) frameLines() [][]byte {
+	result := make(chan [][]byte, 1)
+	select {
+	case p.operateState <- func(s *pState) { result <- copyLines(s.lastLines) }:
+		return <-result
+	case <-p.done:
+		p.cwg.Wait()
+		return p.lastLines
+	}
+}
+
+func copyLines(lines [][]byte) [][]byte {
+	dup := make([][]byte, len(lines))
+	for i, line := range lines {
+		dup[i] = append([]byte(nil), line...)
+	}
+	return dup
+}
+
+// parseSGR splits line into segments of the same style, columns are
+// measured with m. Escape sequences other than SGR are dropped.
+func parseSGR(line string, m decor.Metrics) []segment {
+	var segs []segment
+	var style sgrStyle
+	var sb strings.Builder
+	var col, segCol int
+	emit := func() {
+		if sb.Len() != 0 {
+			segs = append(segs, segment{sb.String(), segCol, style})
+			sb.Reset()
+		}
+		segCol = col
+	}
+	for i := 0; i < len(line); {
+		if line[i] != '\x1b' || i+1 == len(line) || line[i+1] != '[' {
+			j := strings.IndexByte(line[i:], '\x1b')
+			if j < 0 {
+				j = len(line) - i
+			} else if j == 0 {
+				// lone escape byte
+				j = 1
+			}
+			text := line[i : i+j]
+			if text != "\x1b" {
+				sb.WriteString(text)
+				col += m.StringWidth(text)
+			}
+			i += j
+			continue
+		}
+		j := i + 2
+		for j < len(line) && (line[j] < 0x40 || line[j] > 0x7e) {
+			j++
+		}
+		if j == len(line) {
+			break
+		}
+		if line[j] == 'm' {
+			emit()
+			style.apply(line[i+2 : j])
+		}
+		i = j + 1
+	}
+	emit()
+	return segs
+}
+
+// apply applies SGR parameters, for example "38;5;196", to the style.
+func (s *sgrStyle) apply(params string) {
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		n, err := strconv.Atoi(codes[i])
+		if err != nil {
+			n = 0
+		}
+		switch {
+		case n == 0:
+			*s = sgrStyle{}
+		case n == 1:
+			s.bold = true
+		case n == 2:
+			s.dim = true
+		case n == 22:
+			s.bold, s.dim = false, false
+		case n == 7:
+			s.inverse = true
+		case n == 27:
+			s.inverse = false
+		case n >= 30 && n <= 37:
+			s.fg = &basicPalette[n-30]
+		case n >= 90 && n <= 97:
+			s.fg = &basicPalette[n-90+8]
+		case n == 39:
+			s.fg = nil
+		case n >= 40 && n <= 47:
+			s.bg = &basicPalette[n-40]
+		case n >= 100 && n <= 107:
+			s.bg = &basicPalette[n-100+8]
+		case n == 49:
+			s.bg = nil
+		case n == 38 || n == 48:
+			c, skip := extendedColor(codes[i+1:])
+			i += skip
+			if c != nil {
+				if n == 38 {
+					s.fg = c
+				} else {
+					s.bg = c
+				}
+			}
+		}
+	}
+}
+
+// extendedColor parses 256-color or true color parameters, which
+// follow 38 or 48 code, and returns number of parameters consumed.
+func extendedColor(codes []string) (*RGB, int) {
+	num := func(i int) int {
+		if i >= len(codes) {
+			return 0
+		}
+		n, _ := strconv.Atoi(codes[i])
+		return n
+	}
+	switch num(0) {
+	case 5:
+		c := xtermColor(num(1))
+		return &c, 2
+	case 2:
+		return &RGB{uint8(num(1)), uint8(num(2)), uint8(num(3))}, 4
+	}
+	return nil, 1
+}
+
+// xtermColor converts xterm's 256-color palette index to RGB.
+func xtermColor(n int) RGB {
+	switch {
+	case n < 16:
+		if n < 0 {
+			n = 0
+		}
+		return basicPalette[n]
+	case n < 232:
+		level := func(v int) uint8 {
+			if v == 0 {
+				return 0
+			}
+			return uint8(55 + v*40)
+		}
+		n -= 16
+		return RGB{level(n / 36), level(n / 6 % 6), level(n % 6)}
+	default:
+		if n > 255 {
+			n = 255
+		}
+		v := uint8(8 + (n-232)*10)
+		return RGB{v, v, v}
+	}
+}
+
+func (c RGB) hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// css returns inline style of s, fgProp and bgProp name properties
+// to set colors with, empty bgProp drops background.
+func (s sgrStyle) css(fgProp, bgProp string) string {
+	fg, bg := s.fg, s.bg
+	if s.inverse {
+		if fg == nil {
+			fg = &basicPalette[7]
+		}
+		if bg == nil {
+			bg = &basicPalette[0]
+		}
+		fg, bg = bg, fg
+	}
+	var props []string
+	if fg != nil {
+		props = append(props, fgProp+":"+fg.hex())
+	}
+	if bg != nil && bgProp != "" {
+		props = append(props, bgProp+":"+bg.hex())
+	}
+	if s.bold {
+		props = append(props, "font-weight:bold")
+	}
+	if s.dim {
+		props = append(props, "opacity:0.6")
+	}
+	return strings.Join(props, ";")
+}
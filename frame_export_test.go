@@ -0,0 +1,57 @@
+package mpb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vbauerster/mpb/v5/decor"
+)
+
+func TestParseSGR(t *testing.T) {
+	red := basicPalette[1]
+	orange := RGB{255, 135, 0}
+	tests := []struct {
+		line string
+		want []segment
+	}{
+		{
+			line: "plain",
+			want: []segment{{text: "plain"}},
+		},
+		{
+			line: "a\x1b[31mb\x1b[0mc",
+			want: []segment{
+				{text: "a"},
+				{text: "b", col: 1, style: sgrStyle{fg: &red}},
+				{text: "c", col: 2},
+			},
+		},
+		{
+			line: "\x1b[1;38;5;208m日\x1b[22mx",
+			want: []segment{
+				{text: "日", style: sgrStyle{fg: &orange, bold: true}},
+				{text: "x", col: 2, style: sgrStyle{fg: &orange}},
+			},
+		},
+		{
+			line: "\x1b[48;2;1;2;3m \x1b[J",
+			want: []segment{
+				{text: " ", style: sgrStyle{bg: &RGB{1, 2, 3}}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		if got := parseSGR(tc.line, decor.Metrics{}); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q want: %+v, got: %+v", tc.line, tc.want, got)
+		}
+	}
+}
+
+func TestSGRStyleCSS(t *testing.T) {
+	red := basicPalette[1]
+	s := sgrStyle{fg: &red, inverse: true, dim: true}
+	want := "color:#000000;background-color:#cd0000;opacity:0.6"
+	if got := s.css("color", "background-color"); got != want {
+		t.Errorf("want: %q, got: %q", want, got)
+	}
+}
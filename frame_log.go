@@ -0,0 +1,51 @@
+package mpb
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/acarl005/stripansi"
+)
+
+// frameLogger writes periodic plain text snapshots of rendered frames.
+type frameLogger struct {
+	w        io.Writer
+	interval time.Duration
+	last     time.Time
+	buf      bytes.Buffer
+	pending  bool
+	err      error
+}
+
+// update stores plain text copy of lines, and writes it out, if
+// interval has passed since the last snapshot.
+func (l *frameLogger) update(lines [][]byte) {
+	l.buf.Reset()
+	for _, line := range lines {
+		l.buf.WriteString(stripansi.Strip(string(line)))
+		l.buf.WriteByte('\n')
+	}
+	l.pending = true
+	if time.Since(l.last) >= l.interval {
+		l.flush()
+	}
+}
+
+// flush writes pending snapshot, if any, and returns the first write
+// error encountered so far.
+func (l *frameLogger) flush() error {
+	if !l.pending || l.err != nil {
+		return l.err
+	}
+	l.last = time.Now()
+	l.pending = false
+	if _, err := io.WriteString(l.w, "--- "+l.last.Format(time.RFC3339)+" ---\n"); err != nil {
+		l.err = err
+		return err
+	}
+	if _, err := l.buf.WriteTo(l.w); err != nil {
+		l.err = err
+	}
+	return l.err
+}
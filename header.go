@@ -0,0 +1,43 @@
+package mpb
+
+import (
+	"io"
+	"math"
+	"strings"
+
+	"github.com/vbauerster/mpb/v5/decor"
+	"github.com/vbauerster/mpb/v5/internal"
+)
+
+// headerFiller fills bar's space with blanks, so append titles of
+// header row line up with append decorators of ordinary bars.
+type headerFiller struct{}
+
+func (headerFiller) Fill(w io.Writer, reqWidth int, stat decor.Statistics) {
+	width := internal.WidthForBarFiller(reqWidth, stat.AvailableWidth)
+	if width <= 0 {
+		return
+	}
+	io.WriteString(w, strings.Repeat(" ", width))
+}
+
+// newHeaderBar creates pseudo bar, which renders column titles above
+// all bars. Header bar takes part in width sync, but not in container's
+// lifecycle, i.e. it is never completed and isn't waited for.
+func (s *pState) newHeaderBar(p *Progress) *Bar {
+	titles := func(tt []string) []decor.Decorator {
+		decorators := make([]decor.Decorator, len(tt))
+		for i, t := range tt {
+			decorators[i] = decor.Name(t, decor.WCSyncWidthR)
+		}
+		return decorators
+	}
+	bs := s.makeBarState(0, headerFiller{},
+		PrependDecorators(titles(s.headerRow[0])...),
+		AppendDecorators(titles(s.headerRow[1])...),
+	)
+	bs.id = -1
+	bs.priority = math.MinInt32
+	bs.header = true
+	return newBar(p, bs)
+}
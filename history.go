@@ -0,0 +1,73 @@
+package mpb
+
+import (
+	"encoding/csv"
+	"strconv"
+	"time"
+
+	"github.com/vbauerster/mpb/v5/decor"
+)
+
+var historyHeader = []string{"time", "id", "name", "current", "total", "speed"}
+
+type historyMark struct {
+	current int64
+	time    time.Time
+}
+
+// historyRecorder writes timestamped rows of bars' progress as CSV
+// records, at most once per interval.
+type historyRecorder struct {
+	w        *csv.Writer
+	interval time.Duration
+	last     time.Time
+	marks    map[int]historyMark
+	pending  []decor.Statistics
+	err      error
+}
+
+// update stores stats of rendered bars, and writes them out, if
+// interval has passed since the last record.
+func (r *historyRecorder) update(stats []decor.Statistics) {
+	r.pending = append(r.pending[:0], stats...)
+	if time.Since(r.last) >= r.interval {
+		r.flush()
+	}
+}
+
+// flush writes pending rows, if any, and returns the first write error
+// encountered so far.
+func (r *historyRecorder) flush() error {
+	if len(r.pending) == 0 || r.err != nil {
+		return r.err
+	}
+	now := time.Now()
+	if r.marks == nil {
+		r.marks = make(map[int]historyMark)
+		r.w.Write(historyHeader)
+	}
+	for _, st := range r.pending {
+		var speed float64
+		if mark, ok := r.marks[st.ID]; ok {
+			if dur := now.Sub(mark.time).Seconds(); dur > 0 {
+				speed = float64(st.Current-mark.current) / dur
+			}
+		} else if st.Elapsed > 0 {
+			speed = float64(st.Current) / st.Elapsed.Seconds()
+		}
+		r.marks[st.ID] = historyMark{st.Current, now}
+		r.w.Write([]string{
+			now.Format(time.RFC3339Nano),
+			strconv.Itoa(st.ID),
+			st.Name,
+			strconv.FormatInt(st.Current, 10),
+			strconv.FormatInt(st.Total, 10),
+			strconv.FormatFloat(speed, 'f', 2, 64),
+		})
+	}
+	r.last = now
+	r.pending = r.pending[:0]
+	r.w.Flush()
+	r.err = r.w.Error()
+	return r.err
+}
@@ -0,0 +1,58 @@
+// Package term detects capabilities of a terminal.
+package term
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/vbauerster/mpb/v5/cwriter"
+)
+
+// Color depth levels, as reported by ColorDepth.
+const (
+	ColorNone = iota
+	Color256
+	ColorTrue
+)
+
+// Fd returns file descriptor of w, if w is a terminal.
+func Fd(w io.Writer) (int, bool) {
+	f, ok := w.(*os.File)
+	if !ok {
+		return -1, false
+	}
+	fd := int(f.Fd())
+	return fd, cwriter.IsTerminal(fd)
+}
+
+// ColorDepth reports color depth, advertised by terminal via
+// environment variables.
+func ColorDepth() int {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return ColorNone
+	}
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ColorTrue
+	}
+	term := os.Getenv("TERM")
+	switch {
+	case strings.Contains(term, "256color"):
+		return Color256
+	case strings.Contains(term, "truecolor") || strings.Contains(term, "direct"):
+		return ColorTrue
+	}
+	return defaultColorDepth(term)
+}
+
+// Unicode reports whether terminal is expected to render unicode.
+func Unicode() bool {
+	for _, name := range [...]string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			v = strings.ToLower(v)
+			return strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+		}
+	}
+	return defaultUnicode()
+}
@@ -0,0 +1,17 @@
+// +build !windows
+
+package term
+
+func defaultColorDepth(term string) int {
+	return ColorNone
+}
+
+func defaultUnicode() bool {
+	return false
+}
+
+// EnableVT reports whether terminal behind fd processes virtual
+// terminal sequences. It's always true for non windows terminals.
+func EnableVT(fd int) bool {
+	return true
+}
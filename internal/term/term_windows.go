@@ -0,0 +1,44 @@
+// +build windows
+
+package term
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+var procGetConsoleOutputCP = windows.NewLazySystemDLL("kernel32.dll").NewProc("GetConsoleOutputCP")
+
+func defaultColorDepth(term string) int {
+	if os.Getenv("WT_SESSION") != "" {
+		return ColorTrue
+	}
+	if term != "" {
+		// hope it's cygwin or similar
+		return Color256
+	}
+	return ColorNone
+}
+
+func defaultUnicode() bool {
+	if os.Getenv("WT_SESSION") != "" {
+		return true
+	}
+	cp, _, _ := procGetConsoleOutputCP.Call()
+	return cp == 65001
+}
+
+// EnableVT tries to enable virtual terminal processing for the console
+// behind fd and reports whether it's enabled.
+func EnableVT(fd int) bool {
+	var mode uint32
+	h := windows.Handle(fd)
+	if err := windows.GetConsoleMode(h, &mode); err != nil {
+		return false
+	}
+	if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return true
+	}
+	return windows.SetConsoleMode(h, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) == nil
+}
@@ -0,0 +1,56 @@
+package mpb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vbauerster/mpb/v5/decor"
+)
+
+// layoutText is literal text of BarLayout template. It separates
+// adjacent decorators by itself, so no decorator separator is rendered
+// next to it.
+type layoutText struct {
+	decor.Decorator
+}
+
+// separated reports whether decorator separator goes in between
+// adjacent decorators a and b.
+func separated(a, b decor.Decorator) bool {
+	_, aText := a.(layoutText)
+	_, bText := b.(layoutText)
+	return !aText && !bText
+}
+
+// compileLayout splits BarLayout template into prepend and append
+// decorators, see BarLayout.
+func compileLayout(tmpl string, decorators map[string]decor.Decorator) (pDecorators, aDecorators []decor.Decorator) {
+	dest := &pDecorators
+	for len(tmpl) != 0 {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			start = len(tmpl)
+		}
+		if start != 0 {
+			*dest = append(*dest, layoutText{decor.Name(tmpl[:start])})
+			tmpl = tmpl[start:]
+			continue
+		}
+		end := strings.IndexByte(tmpl, '}')
+		if end < 0 {
+			panic(fmt.Sprintf("unterminated layout placeholder: %q", tmpl))
+		}
+		name := tmpl[1:end]
+		tmpl = tmpl[end+1:]
+		if name == "bar" {
+			dest = &aDecorators
+			continue
+		}
+		d, ok := decorators[name]
+		if !ok {
+			panic(fmt.Sprintf("unknown layout placeholder: {%s}", name))
+		}
+		*dest = append(*dest, d)
+	}
+	return pDecorators, aDecorators
+}
@@ -0,0 +1,134 @@
+package mpb
+
+import (
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// ParseRule maps lines of text, matching Pattern, to bar updates. It's
+// meant to re-render progress of external tools, like ffmpeg or rsync,
+// as mpb bars, see NewLineParser.
+type ParseRule struct {
+	// Pattern to match line against.
+	Pattern *regexp.Regexp
+	// Update is called with submatches of each matching line.
+	Update func(bar *Bar, match []string)
+}
+
+// CurrentRule sets bar's current to integer captured by the first
+// group of pattern.
+func CurrentRule(pattern *regexp.Regexp) ParseRule {
+	return ParseRule{pattern, func(bar *Bar, match []string) {
+		if n, ok := parseCapture(match); ok {
+			bar.SetCurrent(int64(n))
+		}
+	}}
+}
+
+// TotalRule sets bar's total to integer captured by the first group
+// of pattern, without triggering complete event.
+func TotalRule(pattern *regexp.Regexp) ParseRule {
+	return ParseRule{pattern, func(bar *Bar, match []string) {
+		if n, ok := parseCapture(match); ok {
+			bar.SetTotal(int64(n), false)
+		}
+	}}
+}
+
+// PercentRule sets bar's current to percentage of its total, captured
+// by the first group of pattern, like "42.5" of rsync's "42.5%".
+func PercentRule(pattern *regexp.Regexp) ParseRule {
+	return ParseRule{pattern, func(bar *Bar, match []string) {
+		if pct, ok := parseCapture(match); ok {
+			total := bar.statistics().Total
+			bar.SetCurrent(int64(math.Round(pct * float64(total) / 100)))
+		}
+	}}
+}
+
+func parseCapture(match []string) (float64, bool) {
+	if len(match) < 2 {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(match[1], 64)
+	return n, err == nil && n >= 0
+}
+
+// NewLineParser returns io.WriteCloser, which splits written text into
+// lines and applies each matching rule to bar. Both '\n' and '\r' are
+// treated as line terminators, as tools usually redraw their progress
+// with carriage return. If passthrough is not nil, every line is
+// written to it with '\n' terminator, for example to
+// *Progress.LogWriter, in order to keep child's output visible. Close
+// flushes the last line, if it's not terminated. For example:
+//
+//	re := regexp.MustCompile(`(\d+(?:\.\d+)?)%`)
+//	cmd.Stdout = mpb.NewLineParser(bar, nil, mpb.PercentRule(re))
+//
+func NewLineParser(bar *Bar, passthrough io.Writer, rules ...ParseRule) io.WriteCloser {
+	return &lineParser{bar: bar, passthrough: passthrough, rules: rules}
+}
+
+type lineParser struct {
+	mu          sync.Mutex
+	bar         *Bar
+	passthrough io.Writer
+	rules       []ParseRule
+	buf         []byte
+}
+
+func (lp *lineParser) Write(p []byte) (int, error) {
+	lp.mu.Lock()
+	defer lp.mu.Unlock()
+	n := len(p)
+	for len(p) > 0 {
+		i := 0
+		for i < len(p) && p[i] != '\n' && p[i] != '\r' {
+			i++
+		}
+		lp.buf = append(lp.buf, p[:i]...)
+		if i == len(p) {
+			break
+		}
+		p = p[i+1:]
+		if err := lp.line(); err != nil {
+			return n - len(p), err
+		}
+	}
+	return n, nil
+}
+
+func (lp *lineParser) line() error {
+	line := lp.buf
+	lp.buf = lp.buf[:0]
+	if len(line) == 0 {
+		// "\r\n" and blank lines
+		return nil
+	}
+	for _, rule := range lp.rules {
+		if match := rule.Pattern.FindSubmatch(line); match != nil {
+			captures := make([]string, len(match))
+			for i, m := range match {
+				captures[i] = string(m)
+			}
+			rule.Update(lp.bar, captures)
+		}
+	}
+	if lp.passthrough == nil {
+		return nil
+	}
+	_, err := lp.passthrough.Write(append(line, '\n'))
+	return err
+}
+
+func (lp *lineParser) Close() error {
+	lp.mu.Lock()
+	defer lp.mu.Unlock()
+	if len(lp.buf) == 0 {
+		return nil
+	}
+	return lp.line()
+}
@@ -0,0 +1,47 @@
+package mpb_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"testing"
+
+	"github.com/vbauerster/mpb/v5"
+)
+
+func TestLineParser(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(ioutil.Discard))
+
+	bar := p.AddBar(200)
+	var passthrough bytes.Buffer
+	lp := mpb.NewLineParser(bar, &passthrough,
+		mpb.PercentRule(regexp.MustCompile(`(\d+(?:\.\d+)?)%`)),
+	)
+	fmt.Fprint(lp, "file.bin\n   10.5%\r   2")
+	fmt.Fprint(lp, "5.0%\r")
+	if got := bar.Current(); got != 50 {
+		t.Errorf("expected current 50, got: %d", got)
+	}
+	fmt.Fprint(lp, "  100%")
+	lp.Close()
+	if !bar.Completed() {
+		t.Error("expected bar to be completed")
+	}
+	if got, want := passthrough.String(), "file.bin\n   10.5%\n   25.0%\n  100%\n"; got != want {
+		t.Errorf("expected passthrough %q, got: %q", want, got)
+	}
+
+	counted := p.AddBar(0)
+	lp = mpb.NewLineParser(counted, nil,
+		mpb.TotalRule(regexp.MustCompile(`of (\d+)`)),
+		mpb.CurrentRule(regexp.MustCompile(`frame (\d+)`)),
+	)
+	fmt.Fprint(lp, "frame 10 of 40\r\nframe 20 of 40\r\n")
+	if got := counted.Current(); got != 20 {
+		t.Errorf("expected current 20, got: %d", got)
+	}
+	counted.Abort(false)
+
+	p.Wait()
+}
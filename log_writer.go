@@ -0,0 +1,97 @@
+package mpb
+
+import (
+	"bytes"
+	"io"
+	"os/exec"
+	"sync"
+)
+
+// LogWriter returns io.WriteCloser, which routes written text above
+// the bars, line by line. Incomplete line is held back until its
+// newline is written, or until Close is called. Each line is prefixed
+// with prefix. It's safe for concurrent use. Text written after
+// container is done goes directly to container's output.
+func (p *Progress) LogWriter(prefix string) io.WriteCloser {
+	return &logWriter{p: p, prefix: []byte(prefix)}
+}
+
+// AttachCmd routes cmd's stdout and stderr above the bars, line by
+// line, so wrapper programs can display both child process output and
+// progress bars cleanly. Must be called before cmd is started. Call
+// Close of returned io.Closer after cmd has finished, in order to
+// flush the last line, if it's not newline terminated.
+func (p *Progress) AttachCmd(cmd *exec.Cmd) io.Closer {
+	stdout, stderr := p.LogWriter(""), p.LogWriter("")
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	return closers{stdout, stderr}
+}
+
+// writeLog queues complete lines to be written above the bars at next
+// render.
+func (p *Progress) writeLog(lines []byte) error {
+	select {
+	case p.operateState <- func(s *pState) {
+		s.logBuf.Write(lines)
+		p.notifyUpdate()
+	}:
+		return nil
+	case <-p.done:
+		p.cwg.Wait()
+		_, err := p.output.Write(lines)
+		return err
+	}
+}
+
+type logWriter struct {
+	mu     sync.Mutex
+	p      *Progress
+	prefix []byte
+	buf    []byte
+}
+
+func (w *logWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	n := len(b)
+	var lines []byte
+	for len(b) > 0 {
+		i := bytes.IndexByte(b, '\n')
+		if i < 0 {
+			w.buf = append(w.buf, b...)
+			break
+		}
+		lines = append(lines, w.prefix...)
+		lines = append(lines, w.buf...)
+		lines = append(lines, b[:i+1]...)
+		w.buf = w.buf[:0]
+		b = b[i+1:]
+	}
+	if lines == nil {
+		return n, nil
+	}
+	return n, w.p.writeLog(lines)
+}
+
+func (w *logWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) == 0 {
+		return nil
+	}
+	line := append(append(append([]byte{}, w.prefix...), w.buf...), '\n')
+	w.buf = w.buf[:0]
+	return w.p.writeLog(line)
+}
+
+type closers []io.Closer
+
+func (cc closers) Close() error {
+	var err error
+	for _, c := range cc {
+		if e := c.Close(); err == nil {
+			err = e
+		}
+	}
+	return err
+}
@@ -0,0 +1,205 @@
+// Package mpbhttp provides resumable HTTP downloads, rendered as mpb
+// progress bars.
+package mpbhttp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vbauerster/mpb/v5"
+)
+
+// averageWarmUp is a period after resume, speed of which is used to
+// adjust average based decorators.
+const averageWarmUp = time.Second
+
+// Option is a function option which changes the default behavior of
+// Download.
+type Option func(*config)
+
+type config struct {
+	ctx        context.Context
+	client     *http.Client
+	barOptions []mpb.BarOption
+}
+
+// WithClient sets http client to use, http.DefaultClient is used by
+// default.
+func WithClient(client *http.Client) Option {
+	return func(c *config) {
+		if client != nil {
+			c.client = client
+		}
+	}
+}
+
+// WithContext sets context of the request.
+func WithContext(ctx context.Context) Option {
+	return func(c *config) {
+		if ctx != nil {
+			c.ctx = ctx
+		}
+	}
+}
+
+// WithBarOptions replaces default bar options, which are file name,
+// counters, eta and speed decorators.
+func WithBarOptions(options ...mpb.BarOption) Option {
+	return func(c *config) {
+		c.barOptions = options
+	}
+}
+
+// Download downloads url into dest file, rendering progress as a bar
+// of p container. If dest exists, download is resumed with a Range
+// request, so bar starts at the size of dest, with already downloaded
+// part displayed as refill, and average based decorators are adjusted
+// to reflect speed of the resumed part only. If server doesn't support
+// ranges, dest is downloaded from scratch. Bar is completed on success
+// or aborted, without being dropped, on error.
+func Download(p *mpb.Progress, url, dest string, options ...Option) error {
+	c := &config{
+		ctx:    context.Background(),
+		client: http.DefaultClient,
+	}
+	for _, opt := range options {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	if c.barOptions == nil {
+		c.barOptions = defaultBarOptions(filepath.Base(dest))
+	}
+
+	var offset int64
+	if fi, err := os.Stat(dest); err == nil {
+		offset = fi.Size()
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(c.ctx)
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	flag := os.O_WRONLY | os.O_CREATE
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// range is not supported, start over
+		offset = 0
+		flag |= os.O_TRUNC
+	case http.StatusPartialContent:
+		if start, ok := contentRangeStart(resp.Header.Get("Content-Range")); !ok || start != offset {
+			return fmt.Errorf("mpbhttp: unexpected Content-Range %q", resp.Header.Get("Content-Range"))
+		}
+		flag |= os.O_APPEND
+	case http.StatusRequestedRangeNotSatisfiable:
+		if offset == 0 {
+			return fmt.Errorf("mpbhttp: %s", resp.Status)
+		}
+		// dest is complete already
+		bar := p.AddBar(offset, c.barOptions...)
+		bar.SetRefill(offset)
+		bar.SetCurrent(offset)
+		return nil
+	default:
+		return fmt.Errorf("mpbhttp: %s", resp.Status)
+	}
+
+	f, err := os.OpenFile(dest, flag, 0644)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	if resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+	bar := p.AddBar(total, c.barOptions...)
+	if total == 0 {
+		// unknown size, complete on EOF only
+		bar.SetTotal(0, false)
+	}
+	if offset > 0 {
+		bar.SetRefill(offset)
+		bar.SetCurrent(offset)
+		// restart average based decorators now, start time is refined
+		// by averageAdjuster after warm up
+		bar.DecoratorAverageAdjust(time.Now())
+	}
+
+	w := io.MultiWriter(f, bar.NewCounter(), &averageAdjuster{
+		bar:    bar,
+		offset: offset,
+		start:  time.Now(),
+	})
+	n, err := io.Copy(w, resp.Body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && resp.ContentLength >= 0 && n != resp.ContentLength {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		bar.Abort(false)
+		return err
+	}
+	bar.SetTotal(-1, true)
+	return nil
+}
+
+func defaultBarOptions(name string) []mpb.BarOption {
+	return []mpb.BarOption{mpb.PresetDownloadBar(name)}
+}
+
+// averageAdjuster refines start time of average based decorators once,
+// after warm up period, so that offset is accounted at speed of the
+// resumed part, rather than inflating their average.
+type averageAdjuster struct {
+	bar    *mpb.Bar
+	offset int64
+	start  time.Time
+	n      int64
+	done   bool
+}
+
+func (a *averageAdjuster) Write(p []byte) (int, error) {
+	a.n += int64(len(p))
+	if a.done || a.offset == 0 {
+		return len(p), nil
+	}
+	if elapsed := time.Since(a.start); elapsed >= averageWarmUp {
+		a.done = true
+		rate := float64(a.n) / elapsed.Seconds()
+		a.bar.DecoratorAverageAdjust(a.start.Add(-time.Duration(float64(a.offset) / rate * float64(time.Second))))
+	}
+	return len(p), nil
+}
+
+// contentRangeStart parses start of "bytes start-end/size" value.
+func contentRangeStart(s string) (int64, bool) {
+	s = strings.TrimPrefix(s, "bytes ")
+	i := strings.IndexByte(s, '-')
+	if i < 0 {
+		return 0, false
+	}
+	start, err := strconv.ParseInt(s[:i], 10, 64)
+	return start, err == nil
+}
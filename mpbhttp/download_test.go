@@ -0,0 +1,97 @@
+package mpbhttp_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vbauerster/mpb/v5"
+	"github.com/vbauerster/mpb/v5/mpbhttp"
+)
+
+func newServer(content []byte, ranges bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !ranges {
+			r.Header.Del("Range")
+		}
+		http.ServeContent(w, r, "file", time.Time{}, bytes.NewReader(content))
+	}))
+}
+
+func TestDownload(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	for name, tc := range map[string]struct {
+		ranges   bool
+		existing int
+		refill   int64
+	}{
+		"fresh":           {true, 0, 0},
+		"resume":          {true, 4000, 4000},
+		"resume no range": {false, 4000, 0},
+		"complete":        {true, len(content), int64(len(content))},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := newServer(content, tc.ranges)
+			defer srv.Close()
+
+			dir, err := ioutil.TempDir("", "mpbhttp")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			dest := filepath.Join(dir, "file")
+			if tc.existing > 0 {
+				if err := ioutil.WriteFile(dest, content[:tc.existing], 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			p := mpb.New(mpb.WithOutput(ioutil.Discard))
+			if err := mpbhttp.Download(p, srv.URL, dest); err != nil {
+				t.Fatal(err)
+			}
+			p.Wait()
+
+			got, err := ioutil.ReadFile(dest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, content) {
+				t.Errorf("content mismatch, got %d bytes", len(got))
+			}
+			snapshot := p.Snapshot()
+			if len(snapshot) != 1 {
+				t.Fatalf("expected 1 bar, got: %d", len(snapshot))
+			}
+			st := snapshot[0]
+			if !st.Completed || st.Current != int64(len(content)) {
+				t.Errorf("expected completed bar at %d, got: %+v", len(content), st)
+			}
+			if st.Refill != tc.refill {
+				t.Errorf("expected refill %d, got: %d", tc.refill, st.Refill)
+			}
+		})
+	}
+}
+
+func TestDownloadNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "mpbhttp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := mpb.New(mpb.WithOutput(ioutil.Discard))
+	if err := mpbhttp.Download(p, srv.URL, filepath.Join(dir, "file")); err == nil {
+		t.Error("expected error")
+	}
+	p.Wait()
+}
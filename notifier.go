@@ -0,0 +1,30 @@
+package mpb
+
+import (
+	"fmt"
+	"io"
+)
+
+// CompletionNotifier is called once all bars are done, see
+// WithCompletionNotifier.
+type CompletionNotifier func(w io.Writer, s Summary)
+
+// OSCNotifier returns CompletionNotifier, which emits OSC 9 desktop
+// notification, supported by iTerm2, Windows Terminal, ConEmu and some
+// others. Nothing is emitted, if output is not a terminal.
+//
+//	`format` printf compatible verbs for completed and total bar
+//	count, like "%d of %d downloads done"
+//
+func OSCNotifier(format string) CompletionNotifier {
+	if format == "" {
+		format = "%d of %d tasks completed"
+	}
+	return func(w io.Writer, s Summary) {
+		if !DetectTerminal(w).IsTTY {
+			return
+		}
+		msg := fmt.Sprintf(format, s.Completed, len(s.Bars))
+		fmt.Fprintf(w, "\x1b]9;%s\x07", msg)
+	}
+}
@@ -0,0 +1,51 @@
+package mpb
+
+import (
+	"github.com/vbauerster/mpb/v5/decor"
+)
+
+// PresetDownloadBar returns BarOption, which bundles decorators
+// commonly used for downloads: name and byte counters on the left,
+// ETA and speed on the right. EWMA based decorators are used, so it
+// is meant to be used with *Bar.ProxyReader, which fulfills EWMA
+// contract. Options passed after the preset, which set a single value,
+// like BarName, take precedence, while decorators passed after the
+// preset are added next to the bundled ones, for example:
+//
+//	p.AddBar(total, mpb.PresetDownloadBar("file.iso"), mpb.BarName("iso"))
+//
+func PresetDownloadBar(name string) BarOption {
+	return barOptions(
+		BarName(name),
+		PrependDecorators(
+			decor.Name(name, decor.WCSyncSpaceR),
+			decor.CountersKibiByte("% .1f / % .1f", decor.WCSyncWidth),
+		),
+		AppendDecorators(
+			decor.OnComplete(decor.EwmaETA(decor.ET_STYLE_GO, 60, decor.WCSyncWidth), "done"),
+			decor.Name(" "),
+			decor.EwmaSpeed(decor.UnitKiB, "% .1f", 60, decor.WCSyncWidth),
+		),
+	)
+}
+
+// PresetTaskBar returns BarOption, which bundles name on the left and
+// percentage on the right. Same precedence rules as for
+// PresetDownloadBar apply.
+func PresetTaskBar(name string) BarOption {
+	return barOptions(
+		BarName(name),
+		PrependDecorators(decor.Name(name, decor.WCSyncSpaceR)),
+		AppendDecorators(decor.OnComplete(decor.Percentage(decor.WCSyncSpace), "done")),
+	)
+}
+
+func barOptions(options ...BarOption) BarOption {
+	return func(s *bState) {
+		for _, opt := range options {
+			if opt != nil {
+				opt(s)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package mpb_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/vbauerster/mpb/v5"
+	"github.com/vbauerster/mpb/v5/decor"
+)
+
+func TestPresetPrecedence(t *testing.T) {
+	var buf bytes.Buffer
+	p := mpb.New(mpb.WithOutput(&buf), mpb.WithWidth(60))
+
+	bar := p.AddBar(10, mpb.PresetTaskBar("task"), mpb.TrimSpace(),
+		mpb.BarName("renamed"),
+		mpb.AppendDecorators(decor.Name("!")),
+	)
+	bar.IncrBy(10)
+	p.Wait()
+
+	if name := p.Snapshot()[0].Name; name != "renamed" {
+		t.Errorf("expected name set after preset to take precedence, got: %q", name)
+	}
+	line := string(getLastLine(buf.Bytes()))
+	if !strings.Contains(line, "task") {
+		t.Errorf("expected preset's name decorator, got: %q", line)
+	}
+	if !strings.HasSuffix(line, "done!") {
+		t.Errorf("expected decorator added next to preset's ones, got: %q", line)
+	}
+}
+
+func TestPresetDownloadBar(t *testing.T) {
+	var buf bytes.Buffer
+	p := mpb.New(mpb.WithOutput(&buf), mpb.WithWidth(80))
+
+	bar := p.AddBar(1024, mpb.PresetDownloadBar("file.iso"))
+	bar.IncrBy(1024)
+	p.Wait()
+
+	line := string(getLastLine(buf.Bytes()))
+	if !strings.Contains(line, "file.iso 1.0 KiB / 1.0 KiB [") {
+		t.Errorf("expected name and counters before the bar, got: %q", line)
+	}
+	if !strings.HasSuffix(line, "=] done 0.0 b/s") {
+		t.Errorf("expected ETA and speed after the bar, got: %q", line)
+	}
+}
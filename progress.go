@@ -9,16 +9,49 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/vbauerster/mpb/v5/cwriter"
 	"github.com/vbauerster/mpb/v5/decor"
 )
 
+// DoneError is returned by *Progress.TryAdd, if container is done,
+// i.e. all its bars have completed after *Progress.Wait() was called.
+var DoneError = fmt.Errorf("%T instance can't be reused after it's done!", (*Progress)(nil))
+
+// IncompleteError is returned by *Progress.WaitWithTimeout, if some
+// bars haven't completed before the deadline and have been aborted.
+type IncompleteError struct {
+	// Bars is statistics of aborted bars, taken right before abort.
+	Bars []decor.Statistics
+}
+
+func (e *IncompleteError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "mpb: %d bar(s) incomplete at deadline:", len(e.Bars))
+	for _, st := range e.Bars {
+		fmt.Fprintf(&b, " #%d", st.ID)
+		if st.Name != "" {
+			fmt.Fprintf(&b, " %q", st.Name)
+		}
+		fmt.Fprintf(&b, " %d/%d", st.Current, st.Total)
+	}
+	return b.String()
+}
+
+const (
+	escSaveCursor    = "\x1b7"
+	escRestoreCursor = "\x1b8"
+)
+
 const (
 	// default RefreshRate
 	prr = 120 * time.Millisecond
+	// suspendCheckInterval is a tick interval of suspend watcher
+	suspendCheckInterval = time.Second
 )
 
 // Progress represents the container that renders Progress bars
@@ -26,19 +59,29 @@ type Progress struct {
 	ctx          context.Context
 	uwg          *sync.WaitGroup
 	cwg          *sync.WaitGroup
-	bwg          *sync.WaitGroup
+	bwg          *barCounter
 	operateState chan func(*pState)
 	done         chan struct{}
 	refreshCh    chan time.Time
+	updateCh     chan struct{}
 	once         sync.Once
 	dlogger      *log.Logger
+	startTime    time.Time
+	output       io.Writer
+	metrics      decor.Metrics
+	// bars, elapsed and lastLines are populated by serve, right
+	// before it quits
+	bars      []*Bar
+	elapsed   time.Duration
+	lastLines [][]byte
 }
 
 type pState struct {
 	bHeap            priorityQueue
 	heapUpdated      bool
-	pMatrix          map[int][]chan int
-	aMatrix          map[int][]chan int
+	bars             []*Bar
+	pMatrix          map[int][]*syncCell
+	aMatrix          map[int][]*syncCell
 	barShutdownQueue []*Bar
 	barPopQueue      []*Bar
 
@@ -52,8 +95,31 @@ type pState struct {
 	renderDelay      <-chan struct{}
 	shutdownNotifier chan struct{}
 	parkedBars       map[*Bar]*Bar
+	summary          func(Summary) string
+	notifiers        []CompletionNotifier
+	termProgress     *termProgress
+	keepAlive        time.Duration
+	idleRefresh      int
+	headerRow        [2][]string
+	syncGroup        *SyncGroup
+	separator        string
+	metrics          decor.Metrics
+	trailingNewlines int
+	cursorRestore    bool
+	asciiOnly        bool
+	cursorSaved      bool
+	header           *Bar
+	frameHook        func([][]byte) [][]byte
+	frameBuf         bytes.Buffer
+	lastLines        [][]byte
+	logBuf           bytes.Buffer
+	frameLog         *frameLogger
+	history          *historyRecorder
+	announcer        *announcer
+	updateCh         chan struct{}
 	output           io.Writer
 	debugOut         io.Writer
+	term             Terminal
 }
 
 // New creates new Progress container instance. It's not possible to
@@ -80,18 +146,38 @@ func NewWithContext(ctx context.Context, options ...ContainerOption) *Progress {
 		}
 	}
 
+	s.term = DetectTerminal(s.output)
+	if s.asciiOnly {
+		s.term.Unicode = false
+	}
+
+	if s.keepAlive > 0 && s.refreshSrc == nil {
+		s.updateCh = make(chan struct{}, 1)
+	}
+
 	p := &Progress{
 		ctx:          ctx,
 		uwg:          s.uwg,
 		cwg:          new(sync.WaitGroup),
-		bwg:          new(sync.WaitGroup),
+		bwg:          newBarCounter(),
 		operateState: make(chan func(*pState)),
 		done:         make(chan struct{}),
+		updateCh:     s.updateCh,
 		dlogger:      log.New(s.debugOut, "[mpb] ", log.Lshortfile),
+		startTime:    time.Now(),
+		output:       s.output,
+		metrics:      s.metrics,
+	}
+
+	if s.headerRow[0] != nil || s.headerRow[1] != nil {
+		s.header = s.newHeaderBar(p)
+		heap.Push(&s.bHeap, s.header)
+		s.heapUpdated = true
 	}
 
 	p.cwg.Add(1)
 	go p.serve(s, cwriter.New(s.output))
+	go p.watchSuspend(suspendCheckInterval)
 	return p
 }
 
@@ -107,15 +193,33 @@ func (p *Progress) AddSpinner(total int64, alignment SpinnerAlignment, options .
 
 // Add creates a bar which renders itself by provided filler.
 // Set total to 0, if you plan to update it later.
-// Panics if *Progress instance is done, i.e. called after *Progress.Wait().
+// Panics if *Progress instance is done, see TryAdd for details.
 func (p *Progress) Add(total int64, filler BarFiller, options ...BarOption) *Bar {
+	bar, err := p.TryAdd(total, filler, options...)
+	if err != nil {
+		panic(err)
+	}
+	return bar
+}
+
+// TryAdd is Add, which returns DoneError instead of panic. It's safe to
+// add bars concurrently with *Progress.Wait(), until all bars added so
+// far have completed, late bars join rendering as usual and Wait waits
+// for them as well. Once Wait has observed all bars done, container is
+// done and DoneError is returned.
+func (p *Progress) TryAdd(total int64, filler BarFiller, options ...BarOption) (*Bar, error) {
 	if filler == nil {
-		filler = BarFillerFunc(func(io.Writer, int, decor.Statistics) {})
+		filler = BarFillerNop()
 	}
-	p.bwg.Add(1)
 	result := make(chan *Bar)
 	select {
 	case p.operateState <- func(ps *pState) {
+		// counted within state op, so that closing counter together
+		// with bars snapshot, see abortIncomplete, is atomic
+		if !p.bwg.add() {
+			result <- nil
+			return
+		}
 		bs := ps.makeBarState(total, filler, options...)
 		bar := newBar(p, bs)
 		if bs.runningBar != nil {
@@ -125,15 +229,18 @@ func (p *Progress) Add(total int64, filler BarFiller, options ...BarOption) *Bar
 			heap.Push(&ps.bHeap, bar)
 			ps.heapUpdated = true
 		}
+		ps.bars = append(ps.bars, bar)
 		ps.idCount++
 		result <- bar
 	}:
 		bar := <-result
+		if bar == nil {
+			return nil, DoneError
+		}
 		bar.subscribeDecorators()
-		return bar
+		return bar, nil
 	case <-p.done:
-		p.bwg.Done()
-		panic(fmt.Sprintf("%T instance can't be reused after it's done!", p))
+		return nil, DoneError
 	}
 }
 
@@ -179,6 +286,50 @@ func (p *Progress) BarCount() int {
 	}
 }
 
+// Snapshot returns statistics of all bars, added to the container
+// so far, in order bars were added. Statistics of each bar is
+// consistent, i.e. taken in between bar's updates. It's meant for
+// status endpoints or UIs, embedded in the same process, which need
+// to display the same data the terminal shows. After container is
+// done, final statistics is returned.
+func (p *Progress) Snapshot() []decor.Statistics {
+	result := make(chan []*Bar, 1)
+	var bars []*Bar
+	select {
+	case p.operateState <- func(s *pState) { result <- s.bars }:
+		bars = <-result
+	case <-p.done:
+		bars = p.doneBars()
+	}
+	snapshot := make([]decor.Statistics, len(bars))
+	for i, b := range bars {
+		snapshot[i] = b.statistics()
+	}
+	return snapshot
+}
+
+// StartTime returns time, container has been created at.
+func (p *Progress) StartTime() time.Time {
+	return p.startTime
+}
+
+// Aggregate returns Summary of all bars, added to the container so
+// far, i.e. sum of their current values and totals, so whole-run
+// throughput can be shown with Summary.Speed, without duplicating
+// bookkeeping. Elapsed is measured since StartTime and stops, once
+// container is done. Don't call it from within decorator, as it
+// waits for the render loop, which runs the decorator.
+func (p *Progress) Aggregate() Summary {
+	result := make(chan []*Bar, 1)
+	select {
+	case p.operateState <- func(s *pState) { result <- s.bars }:
+		return makeSummary(<-result, time.Since(p.startTime))
+	case <-p.done:
+		bars := p.doneBars()
+		return makeSummary(bars, p.elapsed)
+	}
+}
+
 // Wait waits far all bars to complete and finally shutdowns container.
 // After this method has been called, there is no way to reuse *Progress
 // instance.
@@ -188,8 +339,8 @@ func (p *Progress) Wait() {
 		p.uwg.Wait()
 	}
 
-	// wait for bars to quit, if any
-	p.bwg.Wait()
+	// wait for bars to quit, if any, and refuse new ones
+	p.bwg.waitAndClose()
 
 	p.once.Do(p.shutdown)
 
@@ -197,6 +348,122 @@ func (p *Progress) Wait() {
 	p.cwg.Wait()
 }
 
+// WaitWithTimeout is Wait, which doesn't hang forever, if some bar is
+// never completed. Once d has passed, all incomplete bars are aborted,
+// without being dropped, and *IncompleteError reporting them is
+// returned after container shutdown. Bars added past the deadline are
+// refused with DoneError. WaitGroup, provided via WithWaitGroup, isn't
+// waited for past the deadline either, though goroutine blocked on its
+// Wait is released only once it's done.
+func (p *Progress) WaitWithTimeout(d time.Duration) error {
+	expired := make(chan struct{})
+	aborted := make(chan []decor.Statistics, 1)
+	timer := time.AfterFunc(d, func() {
+		close(expired)
+		aborted <- p.abortIncomplete()
+	})
+
+	if p.uwg != nil {
+		uwgDone := make(chan struct{})
+		go func() {
+			p.uwg.Wait()
+			close(uwgDone)
+		}()
+		select {
+		case <-uwgDone:
+		case <-expired:
+		}
+	}
+
+	p.bwg.waitAndClose()
+	p.once.Do(p.shutdown)
+	p.cwg.Wait()
+
+	if timer.Stop() {
+		return nil
+	}
+	if bars := <-aborted; len(bars) != 0 {
+		return &IncompleteError{bars}
+	}
+	return nil
+}
+
+// abortIncomplete refuses new bars, aborts bars, which haven't
+// completed yet, and returns their statistics.
+func (p *Progress) abortIncomplete() []decor.Statistics {
+	result := make(chan []*Bar, 1)
+	select {
+	case p.operateState <- func(s *pState) {
+		p.bwg.close()
+		result <- s.bars
+	}:
+	case <-p.done:
+		return nil
+	}
+	var incomplete []decor.Statistics
+	for _, b := range <-result {
+		if b.Completed() {
+			continue
+		}
+		st := b.statistics()
+		if st.Aborted {
+			continue
+		}
+		b.Abort(false)
+		incomplete = append(incomplete, st)
+	}
+	return incomplete
+}
+
+// watchSuspend detects periods when the process didn't run at all,
+// like after Ctrl-Z or system sleep, by measuring gaps between ticks of
+// otherwise idle goroutine. Detected periods are excluded from bars'
+// active time.
+func (p *Progress) watchSuspend(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := time.Now()
+	for {
+		select {
+		case <-ticker.C:
+			now := time.Now()
+			gap := now.Sub(last)
+			last = now
+			if gap < 3*interval {
+				continue
+			}
+			result := make(chan []*Bar, 1)
+			select {
+			case p.operateState <- func(s *pState) { result <- s.bars }:
+				for _, b := range <-result {
+					b.suspended(gap - interval)
+				}
+			case <-p.done:
+				return
+			}
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// notifyUpdate triggers refresh in refresh on increment mode.
+func (p *Progress) notifyUpdate() {
+	if p.updateCh == nil {
+		return
+	}
+	select {
+	case p.updateCh <- struct{}{}:
+	default:
+	}
+}
+
+// doneBars waits for container to quit and returns all its bars.
+func (p *Progress) doneBars() []*Bar {
+	p.cwg.Wait()
+	return p.bars
+}
+
 func (p *Progress) shutdown() {
 	close(p.done)
 }
@@ -215,16 +482,109 @@ func (p *Progress) serve(s *pState, cw *cwriter.Writer) {
 				p.dlogger.Println(err)
 			}
 		case <-s.shutdownNotifier:
-			if s.heapUpdated {
+			p.bars = s.bars
+			p.elapsed = time.Since(p.startTime)
+			defer func() {
+				p.lastLines = copyLines(s.lastLines)
+			}()
+			if s.heapUpdated || s.logBuf.Len() != 0 {
 				if err := s.render(cw); err != nil {
 					p.dlogger.Println(err)
 				}
 			}
+			if !cw.EndsWithNewline() {
+				// leave cursor below bars, so nothing overwrites them
+				io.WriteString(s.output, "\n")
+			}
+			defer s.finishCursor()
+			if s.header != nil {
+				s.header.cancel()
+			}
+			if s.termProgress != nil && s.term.IsTTY {
+				s.termProgress.finish(s.output)
+			}
+			if s.frameLog != nil {
+				if err := s.frameLog.flush(); err != nil {
+					p.dlogger.Println(err)
+				}
+			}
+			if s.history != nil {
+				if err := s.history.flush(); err != nil {
+					p.dlogger.Println(err)
+				}
+			}
+			if s.announcer != nil {
+				if err := s.announcer.flush(); err != nil {
+					p.dlogger.Println(err)
+				}
+			}
+			if s.summary == nil && len(s.notifiers) == 0 {
+				return
+			}
+			summary := makeSummary(s.bars, p.elapsed)
+			if s.summary != nil {
+				if _, err := fmt.Fprintln(s.output, s.summary(summary)); err != nil {
+					p.dlogger.Println(err)
+				}
+			}
+			for _, fn := range s.notifiers {
+				fn(s.output, summary)
+			}
 			return
 		}
 	}
 }
 
+// barCounter counts running bars. Unlike sync.WaitGroup it's safe to
+// add to it concurrently with waitAndClose, which refuses new bars
+// atomically, once count has dropped to zero. close refuses new bars
+// right away.
+type barCounter struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	n      int
+	closed bool
+}
+
+func newBarCounter() *barCounter {
+	c := new(barCounter)
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *barCounter) add() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return false
+	}
+	c.n++
+	return true
+}
+
+func (c *barCounter) done() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.n--; c.n == 0 {
+		c.cond.Broadcast()
+	}
+}
+
+func (c *barCounter) close() {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+}
+
+func (c *barCounter) waitAndClose() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.n > 0 {
+		c.cond.Wait()
+	}
+	c.closed = true
+}
+
 func (s *pState) newTicker(done <-chan struct{}) chan time.Time {
 	ch := make(chan time.Time)
 	if s.shutdownNotifier == nil {
@@ -235,14 +595,35 @@ func (s *pState) newTicker(done <-chan struct{}) chan time.Time {
 			<-s.renderDelay
 		}
 		if s.refreshSrc == nil {
-			ticker := time.NewTicker(s.rr)
+			interval := s.rr
+			if s.updateCh != nil {
+				interval = s.keepAlive
+			}
+			ticker := time.NewTicker(interval)
 			defer ticker.Stop()
 			s.refreshSrc = ticker.C
 		}
+		var last time.Time
+		var debounce <-chan time.Time
 		for {
 			select {
 			case tick := <-s.refreshSrc:
+				last = time.Now()
 				ch <- tick
+			case <-s.updateCh:
+				if debounce != nil {
+					continue
+				}
+				if wait := s.rr - time.Since(last); wait > 0 {
+					debounce = time.After(wait)
+					continue
+				}
+				last = time.Now()
+				ch <- last
+			case <-debounce:
+				debounce = nil
+				last = time.Now()
+				ch <- last
 			case <-done:
 				close(s.shutdownNotifier)
 				return
@@ -257,27 +638,80 @@ func (s *pState) render(cw *cwriter.Writer) error {
 		s.updateSyncMatrix()
 		s.heapUpdated = false
 	}
-	syncWidth(s.pMatrix)
-	syncWidth(s.aMatrix)
 
 	tw, err := cw.GetWidth()
 	if err != nil {
 		tw = s.reqWidth
 	}
+	if s.cursorRestore && !s.cursorSaved && s.term.IsTTY {
+		cw.WriteString(escSaveCursor)
+		s.cursorSaved = true
+	}
+	for i := 0; i < s.bHeap.Len(); i++ {
+		bar := s.bHeap[i]
+		bar.idle = s.idleRefresh > 1 && bar.canReuseFrame(tw, s.idleRefresh)
+	}
+
+	var pFit, aFit func(int, int) int
+	if s.syncGroup != nil {
+		pFit, aFit = s.syncGroup.prependFit, s.syncGroup.appendFit
+	}
+	syncWidth(s.pMatrix, pFit)
+	syncWidth(s.aMatrix, aFit)
+
 	for i := 0; i < s.bHeap.Len(); i++ {
 		bar := s.bHeap[i]
+		if bar.idle {
+			bar.idleTicks++
+			bar.frameCh <- bytes.NewReader(bar.lastFrame)
+			continue
+		}
+		bar.idleTicks = 0
+		bar.frameWidth = tw
 		go bar.render(tw)
 	}
 
 	return s.flush(cw)
 }
 
+// finishCursor writes trailing newlines and restores saved cursor
+// position, if requested by user, as the very last output.
+func (s *pState) finishCursor() {
+	if s.trailingNewlines > 0 {
+		io.WriteString(s.output, strings.Repeat("\n", s.trailingNewlines))
+	}
+	if s.cursorSaved {
+		io.WriteString(s.output, escRestoreCursor)
+	}
+}
+
 func (s *pState) flush(cw *cwriter.Writer) error {
 	var lineCount int
+	var current, total int64
+	// frame is buffered, so it may be hooked, logged or exported
+	frame := &s.frameBuf
+	frame.Reset()
+	// log lines go first, they are not counted, so they stay above bars
+	s.logBuf.WriteTo(cw)
+	var stats []decor.Statistics
 	bm := make(map[*Bar]struct{}, s.bHeap.Len())
 	for s.bHeap.Len() > 0 {
 		b := heap.Pop(&s.bHeap).(*Bar)
-		cw.ReadFrom(<-b.frameCh)
+		var fr io.Reader = <-b.frameCh
+		if s.idleRefresh > 1 && !b.idle {
+			b.lastFrame, _ = ioutil.ReadAll(fr)
+			fr = bytes.NewReader(b.lastFrame)
+		}
+		if b.silent {
+			io.Copy(ioutil.Discard, fr)
+		} else {
+			frame.ReadFrom(fr)
+		}
+		current += b.lastStat.Current
+		total += b.lastStat.Total
+		if (s.history != nil || s.announcer != nil) && !b.header {
+			stats = append(stats, b.lastStat)
+		}
 		if b.toShutdown {
 			if b.recoveredPanic != nil {
 				s.barShutdownQueue = append(s.barShutdownQueue, b)
@@ -290,7 +724,9 @@ func (s *pState) flush(cw *cwriter.Writer) error {
 				}()
 			}
 		}
-		lineCount += b.extendedLines + 1
+		if !b.silent {
+			lineCount += b.extendedLines + 1
+		}
 		bm[b] = struct{}{}
 	}
 
@@ -315,10 +751,19 @@ func (s *pState) flush(cw *cwriter.Writer) error {
 	}
 	s.barShutdownQueue = s.barShutdownQueue[0:0]
 
+	if s.history != nil {
+		s.history.update(stats)
+	}
+	if s.announcer != nil {
+		s.announcer.update(stats)
+	}
+
 	for _, b := range s.barPopQueue {
 		delete(bm, b)
 		s.heapUpdated = true
-		lineCount -= b.extendedLines + 1
+		if !b.silent {
+			lineCount -= b.extendedLines + 1
+		}
 	}
 	s.barPopQueue = s.barPopQueue[0:0]
 
@@ -326,36 +771,79 @@ func (s *pState) flush(cw *cwriter.Writer) error {
 		heap.Push(&s.bHeap, b)
 	}
 
+	lineCount = s.writeFrame(cw, lineCount)
+
+	if s.termProgress != nil && s.term.IsTTY {
+		s.termProgress.write(cw, current, total)
+	}
+
 	return cw.Flush(lineCount)
 }
 
+// writeFrame passes buffered frame through the frame hook and frame
+// logger, if any, writes result into cw and returns adjusted line
+// count. Written lines are kept till next flush, see WriteHTML.
+func (s *pState) writeFrame(cw *cwriter.Writer, lineCount int) int {
+	var lines [][]byte
+	if s.frameBuf.Len() != 0 {
+		lines = bytes.Split(bytes.TrimSuffix(s.frameBuf.Bytes(), []byte("\n")), []byte("\n"))
+	}
+	// popped bars are rendered for the last time, their lines stay on
+	// top and are not cleared at next flush
+	pinned := len(lines) - lineCount
+	if s.frameHook != nil {
+		lines = s.frameHook(lines)
+	}
+	if s.frameLog != nil {
+		s.frameLog.update(lines)
+	}
+	s.lastLines = lines
+	for _, line := range lines {
+		cw.Write(line)
+		cw.Write([]byte("\n"))
+	}
+	if lineCount = len(lines) - pinned; lineCount < 0 {
+		return 0
+	}
+	return lineCount
+}
+
 func (s *pState) updateSyncMatrix() {
-	s.pMatrix = make(map[int][]chan int)
-	s.aMatrix = make(map[int][]chan int)
+	s.pMatrix = make(map[int][]*syncCell)
+	s.aMatrix = make(map[int][]*syncCell)
 	for i := 0; i < s.bHeap.Len(); i++ {
 		bar := s.bHeap[i]
 		table := bar.wSyncTable()
 		pRow, aRow := table[0], table[1]
 
 		for i, ch := range pRow {
-			s.pMatrix[i] = append(s.pMatrix[i], ch)
+			s.pMatrix[i] = append(s.pMatrix[i], &syncCell{bar: bar, ch: ch})
 		}
 
 		for i, ch := range aRow {
-			s.aMatrix[i] = append(s.aMatrix[i], ch)
+			s.aMatrix[i] = append(s.aMatrix[i], &syncCell{bar: bar, ch: ch})
 		}
+		// new matrix, new widths
+		bar.lastFrame = nil
 	}
 }
 
 func (s *pState) makeBarState(total int64, filler BarFiller, options ...BarOption) *bState {
 	bs := &bState{
-		id:       s.idCount,
-		priority: s.idCount,
-		reqWidth: s.reqWidth,
-		total:    total,
-		filler:   filler,
-		extender: func(r io.Reader, _ int, _ decor.Statistics) (io.Reader, int) { return r, 0 },
-		debugOut: s.debugOut,
+		id:         s.idCount,
+		priority:   s.idCount,
+		reqWidth:   s.reqWidth,
+		total:      total,
+		filler:     filler,
+		lastUpdate: time.Now(),
+		startTime:  time.Now(),
+		attempt:    1,
+		scale:      1,
+		extender:   func(r io.Reader, _ int, _ decor.Statistics) (io.Reader, int) { return r, 0 },
+		separator:  s.separator,
+		metrics:    s.metrics,
+		debugOut:   s.debugOut,
+		term:       s.term,
 	}
 
 	for _, opt := range options {
@@ -364,6 +852,9 @@ func (s *pState) makeBarState(total int64, filler BarFiller, options ...BarOptio
 		}
 	}
 
+	bs.adaptTerminal()
+	bs.applyMetrics()
+
 	if bs.middleware != nil {
 		bs.filler = bs.middleware(filler)
 		bs.middleware = nil
@@ -380,18 +871,54 @@ func (s *pState) makeBarState(total int64, filler BarFiller, options ...BarOptio
 	return bs
 }
 
-func syncWidth(matrix map[int][]chan int) {
-	for _, column := range matrix {
-		column := column
+// syncCell is a cell of width sync matrix. Idle bars don't render,
+// so their cells contribute width, which was synced last time.
+type syncCell struct {
+	bar  *Bar
+	ch   chan int
+	last int
+}
+
+// syncWidth syncs width of each column of the matrix, optional fit
+// func widens column beyond its widest cell, see SyncGroup.
+func syncWidth(matrix map[int][]*syncCell, fit func(column, width int) int) {
+	for i, column := range matrix {
+		i, column := i, column
+		idle := make([]bool, len(column))
+		var active bool
+		for i, c := range column {
+			idle[i] = c.bar.idle
+			active = active || !idle[i]
+		}
+		if !active {
+			continue
+		}
 		go func() {
 			var maxWidth int
-			for _, ch := range column {
-				if w := <-ch; w > maxWidth {
+			for i, c := range column {
+				w := c.last
+				if !idle[i] {
+					w = <-c.ch
+				}
+				if w > maxWidth {
 					maxWidth = w
 				}
 			}
-			for _, ch := range column {
-				ch <- maxWidth
+			if fit != nil {
+				maxWidth = fit(i, maxWidth)
+			}
+			for i, c := range column {
+				if !idle[i] {
+					c.last = maxWidth
+				} else if c.last != maxWidth {
+					// idle frame doesn't fit, render it next time
+					atomic.StoreInt32(&c.bar.stale, 1)
+				}
+			}
+			for i, c := range column {
+				if !idle[i] {
+					c.ch <- maxWidth
+				}
 			}
 		}()
 	}
@@ -3,13 +3,21 @@ package mpb_test
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"math/rand"
+	"os"
+	"os/exec"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/vbauerster/mpb/v5"
+	"github.com/vbauerster/mpb/v5/decor"
 )
 
 func init() {
@@ -107,6 +115,430 @@ func TestWithContext(t *testing.T) {
 	}
 }
 
+func TestWithSummary(t *testing.T) {
+	var buf bytes.Buffer
+	p := mpb.New(
+		mpb.WithOutput(&buf),
+		mpb.WithSummary(func(s mpb.Summary) string {
+			return fmt.Sprintf("summary: %d/%d bars, %d/%d", s.Completed, len(s.Bars), s.Current, s.Total)
+		}),
+	)
+
+	for i := 0; i < 3; i++ {
+		b := p.AddBar(10)
+		go func() {
+			for i := 0; i < 10; i++ {
+				b.Increment()
+				time.Sleep(randomDuration(10 * time.Millisecond))
+			}
+		}()
+	}
+
+	p.Wait()
+
+	want := "summary: 3/3 bars, 30/30"
+	if got := string(getLastLine(buf.Bytes())); got != want {
+		t.Errorf("want: %q, got: %q\n", want, got)
+	}
+}
+
+func TestWithCompletionNotifier(t *testing.T) {
+	var calls, completed int
+	var buf bytes.Buffer
+	p := mpb.New(
+		mpb.WithOutput(&buf),
+		mpb.WithCompletionNotifier(func(w io.Writer, s mpb.Summary) {
+			calls++
+			completed = s.Completed
+		}),
+		// output isn't a terminal, so nothing should be emitted
+		mpb.WithCompletionNotifier(mpb.OSCNotifier("")),
+	)
+
+	bar := p.AddBar(10)
+	bar.IncrBy(10)
+	aborted := p.AddBar(10)
+	aborted.Abort(false)
+
+	p.Wait()
+
+	if calls != 1 {
+		t.Errorf("expected notifier to be called once, got: %d", calls)
+	}
+	if completed != 1 {
+		t.Errorf("expected 1 completed bar, got: %d", completed)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("\x1b]9;")) {
+		t.Error("unexpected OSC sequence in non terminal output")
+	}
+}
+
+func TestWithRefreshOnIncrement(t *testing.T) {
+	p := mpb.New(
+		mpb.WithOutput(ioutil.Discard),
+		mpb.WithWidth(80),
+		mpb.WithRefreshRate(10*time.Millisecond),
+		mpb.WithRefreshOnIncrement(time.Hour),
+	)
+
+	var mu sync.Mutex
+	rendered := make(map[int64]bool)
+	bar := p.AddBar(3, mpb.AppendDecorators(
+		decor.Any(func(s decor.Statistics) string {
+			mu.Lock()
+			rendered[s.Current] = true
+			mu.Unlock()
+			return ""
+		}),
+	))
+	for i := 0; i < 3; i++ {
+		time.Sleep(50 * time.Millisecond)
+		bar.Increment()
+	}
+
+	p.Wait()
+
+	// each increment is expected to be rendered, despite of keep alive
+	// tick which never happens
+	mu.Lock()
+	defer mu.Unlock()
+	for i := int64(1); i <= 3; i++ {
+		if !rendered[i] {
+			t.Errorf("current %d has not been rendered", i)
+		}
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(ioutil.Discard))
+
+	a := p.AddBar(10, mpb.BarName("a"))
+	b := p.AddBar(20, mpb.PresetTaskBar("b"))
+	a.IncrBy(3)
+	b.IncrBy(5)
+
+	snapshot := p.Snapshot()
+	if len(snapshot) != 2 {
+		t.Fatalf("expected 2 bars, got: %d", len(snapshot))
+	}
+	for i, want := range []decor.Statistics{
+		{Name: "a", Current: 3, Total: 10},
+		{Name: "b", Current: 5, Total: 20},
+	} {
+		got := snapshot[i]
+		if got.Name != want.Name || got.Current != want.Current || got.Total != want.Total {
+			t.Errorf("bar#%d want: %s %d/%d, got: %s %d/%d", i,
+				want.Name, want.Current, want.Total, got.Name, got.Current, got.Total)
+		}
+	}
+
+	a.IncrBy(7)
+	b.IncrBy(15)
+	p.Wait()
+
+	for _, st := range p.Snapshot() {
+		if !st.Completed {
+			t.Errorf("bar %q expected to be completed", st.Name)
+		}
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	before := time.Now()
+	p := mpb.New(mpb.WithOutput(ioutil.Discard))
+	if start := p.StartTime(); start.Before(before) || start.After(time.Now()) {
+		t.Errorf("unexpected start time: %s", start)
+	}
+
+	a := p.AddBar(10)
+	b := p.AddBar(20)
+	a.IncrBy(4)
+	b.IncrBy(6)
+
+	agg := p.Aggregate()
+	if agg.Current != 10 || agg.Total != 30 || agg.Completed != 0 {
+		t.Errorf("want: 10/30 with 0 completed, got: %d/%d with %d completed",
+			agg.Current, agg.Total, agg.Completed)
+	}
+
+	a.IncrBy(6)
+	b.IncrBy(14)
+	p.Wait()
+
+	agg = p.Aggregate()
+	if agg.Current != 30 || agg.Completed != 2 {
+		t.Errorf("want: 30 with 2 completed, got: %d with %d completed",
+			agg.Current, agg.Completed)
+	}
+	if agg.Speed() <= 0 {
+		t.Errorf("expected positive overall speed, got: %f", agg.Speed())
+	}
+	if again := p.Aggregate(); again.Elapsed != agg.Elapsed {
+		t.Errorf("elapsed expected to stop once container is done")
+	}
+}
+
+func TestWithSyncGroup(t *testing.T) {
+	group := mpb.NewSyncGroup()
+
+	var lines []string
+	for _, name := range []string{"long phase name", "short"} {
+		var buf bytes.Buffer
+		p := mpb.New(mpb.WithOutput(&buf), mpb.WithWidth(80), mpb.WithSyncGroup(group))
+		bar := p.Add(1, nil, mpb.TrimSpace(),
+			mpb.PrependDecorators(decor.Name(name, decor.WCSyncWidthR)),
+			mpb.AppendDecorators(decor.Name("|")),
+		)
+		bar.Increment()
+		p.Wait()
+		line := string(getLastLine(buf.Bytes()))
+		if i := strings.LastIndex(line, "\x1b[J"); i >= 0 {
+			line = line[i+3:]
+		}
+		lines = append(lines, line)
+	}
+
+	if want := "short          |"; lines[1] != want {
+		t.Errorf("want: %q, got: %q\n", want, lines[1])
+	}
+	if len(lines[0]) != len(lines[1]) {
+		t.Errorf("columns aren't aligned across containers: %q vs %q", lines[0], lines[1])
+	}
+}
+
+func TestWaitWithTimeout(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(ioutil.Discard))
+
+	done := p.AddBar(10, mpb.BarName("done"))
+	stuck := p.AddBar(10, mpb.BarName("stuck"))
+	done.IncrBy(10)
+	stuck.IncrBy(3)
+
+	start := time.Now()
+	err := p.WaitWithTimeout(50 * time.Millisecond)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("WaitWithTimeout took too long: %s", elapsed)
+	}
+
+	e, ok := err.(*mpb.IncompleteError)
+	if !ok {
+		t.Fatalf("expected *mpb.IncompleteError, got: %v", err)
+	}
+	if len(e.Bars) != 1 || e.Bars[0].Name != "stuck" || e.Bars[0].Current != 3 {
+		t.Errorf("unexpected incomplete bars: %v", e)
+	}
+
+	p = mpb.New(mpb.WithOutput(ioutil.Discard))
+	p.AddBar(10).IncrBy(10)
+	if err := p.WaitWithTimeout(time.Second); err != nil {
+		t.Errorf("expected nil error, got: %v", err)
+	}
+}
+
+func TestWaitWithTimeoutRefusesLateBars(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(ioutil.Discard))
+	p.AddBar(10).IncrBy(3)
+
+	if n := p.AbortIncomplete(); n != 1 {
+		t.Errorf("expected 1 incomplete bar, got: %d", n)
+	}
+	if _, err := p.TryAdd(10, nil); err != mpb.DoneError {
+		t.Errorf("expected DoneError, got: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		p.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("Wait hung after incomplete bars were aborted")
+	}
+}
+
+func TestWriteHTMLAndSVG(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(ioutil.Discard), mpb.WithWidth(40))
+
+	bar := p.AddBar(10, mpb.TrimSpace(),
+		mpb.PrependDecorators(decor.Any(func(decor.Statistics) string {
+			return "\x1b[31m<done>\x1b[0m"
+		})),
+	)
+	bar.IncrBy(10)
+	p.Wait()
+
+	var html bytes.Buffer
+	if err := p.WriteHTML(&html); err != nil {
+		t.Fatal(err)
+	}
+	want := `<span style="color:#cd0000">&lt;done&gt;</span>`
+	if !strings.HasPrefix(html.String(), `<pre class="mpb">`) || !strings.Contains(html.String(), want) {
+		t.Errorf("expected html with %q, got: %q", want, html.String())
+	}
+
+	var svg bytes.Buffer
+	if err := p.WriteSVG(&svg); err != nil {
+		t.Fatal(err)
+	}
+	want = `<tspan x="8.0" style="fill:#cd0000">&lt;done&gt;</tspan>`
+	if !strings.HasPrefix(svg.String(), "<svg") || !strings.Contains(svg.String(), want) {
+		t.Errorf("expected svg with %q, got: %q", want, svg.String())
+	}
+}
+
+func TestAddFromTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	p := mpb.New(mpb.WithOutput(&buf), mpb.WithWidth(40))
+
+	var calls int
+	tmpl := mpb.NewBarTemplate(nil,
+		mpb.PresetTaskBar,
+		func(name string) mpb.BarOption {
+			calls++
+			return mpb.AppendDecorators(decor.Name("|" + name))
+		},
+	)
+
+	names := []string{"a", "b", "c"}
+	bars := make([]*mpb.Bar, len(names))
+	for i, name := range names {
+		bars[i] = p.AddFromTemplate(tmpl, 10, name, mpb.BarPriority(len(names)-i))
+	}
+	for _, bar := range bars {
+		bar.IncrBy(10)
+	}
+	p.Wait()
+
+	if calls != len(names) {
+		t.Errorf("expected option func to be called %d times, got: %d", len(names), calls)
+	}
+	for i, st := range p.Snapshot() {
+		if st.Name != names[i] || !st.Completed {
+			t.Errorf("bar#%d: want completed %q, got: %q completed=%t", i, names[i], st.Name, st.Completed)
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := "|a"
+	if got := lines[len(lines)-1]; !strings.HasSuffix(got, want) {
+		t.Errorf("expected bottom line ending with %q, got: %q", want, got)
+	}
+}
+
+func TestWithRenderFrameHook(t *testing.T) {
+	var buf bytes.Buffer
+	header := []byte("== header ==")
+	p := mpb.New(
+		mpb.WithOutput(&buf),
+		mpb.WithWidth(80),
+		mpb.WithRenderFrameHook(func(lines [][]byte) [][]byte {
+			return append([][]byte{header}, lines...)
+		}),
+	)
+
+	bar := p.AddBar(10, mpb.PrependDecorators(decor.Name("task")))
+	bar.IncrBy(10)
+
+	p.Wait()
+
+	lines := bytes.Split(bytes.TrimSuffix(buf.Bytes(), []byte("\n")), []byte("\n"))
+	if len(lines) < 2 {
+		t.Fatalf("expected at least 2 lines, got: %q", buf.String())
+	}
+	last, prev := lines[len(lines)-1], lines[len(lines)-2]
+	if !bytes.HasSuffix(prev, header) {
+		t.Errorf("expected header line, got: %q", prev)
+	}
+	if !bytes.HasPrefix(last, []byte("task")) {
+		t.Errorf("expected bar line, got: %q", last)
+	}
+}
+
+func TestWithLogOutput(t *testing.T) {
+	var log bytes.Buffer
+	p := mpb.New(
+		mpb.WithOutput(ioutil.Discard),
+		mpb.WithWidth(80),
+		mpb.WithLogOutput(&log, time.Hour),
+	)
+
+	bar := p.AddBar(10,
+		mpb.PrependDecorators(decor.Name("\x1b[31mred\x1b[0m ")),
+		mpb.AppendDecorators(decor.CountersNoUnit("%d/%d")),
+	)
+	bar.IncrBy(10)
+
+	p.Wait()
+
+	if bytes.ContainsRune(log.Bytes(), '\x1b') {
+		t.Errorf("unexpected escape sequence in log: %q", log.String())
+	}
+	// at most the first and the last frames are expected
+	if n := bytes.Count(log.Bytes(), []byte("--- ")); n < 1 || n > 2 {
+		t.Errorf("expected 1 or 2 snapshots, got: %d", n)
+	}
+	if last := getLastLine(log.Bytes()); !bytes.HasPrefix(last, []byte("red ")) || !bytes.HasSuffix(last, []byte("10/10")) {
+		t.Errorf("unexpected last snapshot line: %q", last)
+	}
+}
+
+func TestBarSetSilent(t *testing.T) {
+	var buf bytes.Buffer
+	refresh := make(chan time.Time)
+	p := mpb.New(
+		mpb.WithOutput(&buf),
+		mpb.WithWidth(80),
+		mpb.WithManualRefresh(refresh),
+	)
+
+	bar := p.AddBar(10, mpb.PrependDecorators(decor.Name("hidden")))
+	bar.SetSilent(true)
+	refresh <- time.Now()
+	bar.IncrBy(10)
+
+	p.Wait()
+
+	if bytes.Contains(buf.Bytes(), []byte("hidden")) {
+		t.Errorf("silent bar is expected to stay hidden, got: %q", buf.String())
+	}
+	if !bar.Completed() {
+		t.Error("silent bar is expected to complete")
+	}
+}
+
+func TestTryAddWhileWaiting(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(ioutil.Discard))
+
+	first := p.AddBar(10)
+
+	waitDone := make(chan struct{})
+	go func() {
+		p.Wait()
+		close(waitDone)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	late, err := p.TryAdd(10, nil)
+	if err != nil {
+		t.Fatalf("expected late bar to be added, got: %v", err)
+	}
+
+	first.IncrBy(10)
+	select {
+	case <-waitDone:
+		t.Fatal("Wait returned before late bar completed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	late.IncrBy(10)
+	<-waitDone
+
+	if _, err := p.TryAdd(10, nil); err != mpb.DoneError {
+		t.Errorf("expected %v, got: %v", mpb.DoneError, err)
+	}
+}
+
 func getLastLine(bb []byte) []byte {
 	split := bytes.Split(bb, []byte("\n"))
 	return split[len(split)-2]
@@ -115,3 +547,249 @@ func getLastLine(bb []byte) []byte {
 func randomDuration(max time.Duration) time.Duration {
 	return time.Duration(rand.Intn(10)+1) * max / 10
 }
+
+func TestWithIdleRefresh(t *testing.T) {
+	refresh := make(chan time.Time)
+	var frames, badFrames int32
+	p := mpb.New(
+		mpb.WithOutput(ioutil.Discard),
+		mpb.WithWidth(80),
+		mpb.WithManualRefresh(refresh),
+		mpb.WithIdleRefresh(5),
+		mpb.WithRenderFrameHook(func(lines [][]byte) [][]byte {
+			atomic.AddInt32(&frames, 1)
+			if len(lines) != 2 || len(lines[0]) != len(lines[1]) {
+				atomic.AddInt32(&badFrames, 1)
+			}
+			return lines
+		}),
+	)
+
+	var idleCalls, activeCalls int32
+	counter := func(n *int32) decor.Decorator {
+		return decor.Any(func(decor.Statistics) string {
+			atomic.AddInt32(n, 1)
+			return "x"
+		}, decor.WCSyncWidth)
+	}
+	idle := p.AddBar(100, mpb.PrependDecorators(counter(&idleCalls)))
+	active := p.AddBar(100, mpb.PrependDecorators(counter(&activeCalls)))
+
+	for i := 0; i < 50; i++ {
+		active.Increment()
+		refresh <- time.Now()
+	}
+	active.Abort(false)
+	idle.Abort(false)
+	p.Wait()
+
+	if badFrames != 0 {
+		t.Errorf("%d of %d frames are inconsistent", badFrames, frames)
+	}
+	if idleCalls*2 > activeCalls {
+		t.Errorf("idle bar rendered too often: idle %d, active %d", idleCalls, activeCalls)
+	}
+}
+
+func TestWithHeaderRow(t *testing.T) {
+	var buf bytes.Buffer
+	p := mpb.New(
+		mpb.WithOutput(&buf),
+		mpb.WithWidth(40),
+		mpb.WithHeaderRow([]string{"NAME"}, []string{"DONE"}),
+	)
+
+	for _, name := range []string{"a", "longer-name"} {
+		bar := p.AddBar(100,
+			mpb.PrependDecorators(decor.Name(name, decor.WCSyncSpaceR)),
+			mpb.AppendDecorators(decor.Percentage(decor.WCSyncWidth)),
+		)
+		bar.SetTotal(100, true)
+	}
+
+	p.Wait()
+
+	// last frame only
+	out := buf.Bytes()
+	if i := bytes.LastIndex(out, []byte("\x1b[J")); i >= 0 {
+		out = out[i+3:]
+	}
+	lines := bytes.Split(bytes.TrimSuffix(out, []byte("\n")), []byte("\n"))
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got: %q", out)
+	}
+	header, first, second := lines[0], lines[1], lines[2]
+	if !bytes.HasPrefix(header, []byte("NAME")) || !bytes.HasPrefix(first, []byte("a ")) {
+		t.Errorf("NAME title is misaligned: %q vs %q", header, first)
+	}
+	if i := bytes.Index(header, []byte("DONE")); i < 0 || i != bytes.Index(second, []byte("100 %")) {
+		t.Errorf("DONE title is misaligned: %q vs %q", header, second)
+	}
+}
+
+func TestWithHistoryRecorder(t *testing.T) {
+	var buf bytes.Buffer
+	p := mpb.New(
+		mpb.WithOutput(ioutil.Discard),
+		mpb.WithHistoryRecorder(csv.NewWriter(&buf), time.Hour),
+	)
+
+	a := p.AddBar(10, mpb.BarName("a"))
+	b := p.AddBar(20, mpb.BarName("b"))
+	a.IncrBy(10)
+	b.IncrBy(20)
+
+	p.Wait()
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) < 3 {
+		t.Fatalf("expected at least 3 records, got: %q", records)
+	}
+	if got := strings.Join(records[0], ","); got != "time,id,name,current,total,speed" {
+		t.Errorf("unexpected header: %q", got)
+	}
+	last := records[len(records)-2:]
+	for i, want := range [][]string{{"a", "10", "10"}, {"b", "20", "20"}} {
+		if got := last[i][2:5]; strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("expected %q, got: %q", want, got)
+		}
+		if _, err := time.Parse(time.RFC3339Nano, last[i][0]); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestWithTrailingNewlines(t *testing.T) {
+	var buf bytes.Buffer
+	p := mpb.New(
+		mpb.WithOutput(&buf),
+		mpb.WithTrailingNewlines(2),
+	)
+
+	// extender which doesn't terminate its line
+	ext := mpb.BarFillerFunc(func(w io.Writer, _ int, _ decor.Statistics) {
+		io.WriteString(w, "no newline")
+	})
+	bar := p.AddBar(10, mpb.BarExtender(ext))
+	bar.IncrBy(10)
+
+	p.Wait()
+
+	if !bytes.HasSuffix(buf.Bytes(), []byte("no newline\n\n\n")) {
+		t.Errorf("expected cursor below bars plus 2 blank lines, got: %q", buf.String())
+	}
+}
+
+func TestWithASCIIOnly(t *testing.T) {
+	var buf bytes.Buffer
+	p := mpb.New(
+		mpb.WithOutput(&buf),
+		mpb.WithWidth(60),
+		mpb.WithASCIIOnly(),
+	)
+
+	bar := p.Add(100,
+		mpb.NewProgressiveBarFiller(mpb.BrailleBarStyle, false),
+		mpb.PrependDecorators(
+			decor.Spinner(nil),
+			decor.Name("a very long task name", decor.WC{W: 10, C: decor.DTruncate}),
+		),
+	)
+	bar.IncrBy(50)
+	bar.Abort(false)
+
+	p.Wait()
+
+	for i, b := range buf.Bytes() {
+		if b >= 0x80 {
+			t.Fatalf("non ASCII byte at %d: %q", i, buf.String())
+		}
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("a very ...")) {
+		t.Errorf("expected ASCII ellipsis, got: %q", buf.String())
+	}
+}
+
+func TestLogWriter(t *testing.T) {
+	var buf bytes.Buffer
+	p := mpb.New(mpb.WithOutput(&buf), mpb.WithWidth(80))
+
+	bar := p.AddBar(10, mpb.PrependDecorators(decor.Name("task")))
+	w := p.LogWriter("> ")
+	fmt.Fprint(w, "first line\nsecond ")
+	fmt.Fprint(w, "line\nincomplete")
+	w.Close()
+	bar.IncrBy(10)
+
+	p.Wait()
+
+	out := buf.String()
+	for _, want := range []string{"> first line\n", "> second line\n", "> incomplete\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output: %q", want, out)
+		}
+	}
+	if i, j := strings.Index(out, "> incomplete"), strings.LastIndex(out, "task"); i > j {
+		t.Errorf("expected log lines above bar: %q", out)
+	}
+}
+
+func TestAttachCmd(t *testing.T) {
+	var buf bytes.Buffer
+	p := mpb.New(mpb.WithOutput(&buf))
+
+	cmd := exec.Command(os.Args[0], "-test.run=TestHelperProcess")
+	cmd.Env = append(os.Environ(), "MPB_HELPER_PROCESS=1")
+	closer := p.AttachCmd(cmd)
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+	closer.Close()
+
+	p.Wait()
+
+	for _, want := range []string{"to stdout\n", "to stderr\n", "no newline\n"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in output: %q", want, buf.String())
+		}
+	}
+}
+
+// TestHelperProcess isn't a real test, it's used as a child process
+// by TestAttachCmd.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("MPB_HELPER_PROCESS") != "1" {
+		return
+	}
+	fmt.Fprintln(os.Stdout, "to stdout")
+	fmt.Fprintln(os.Stderr, "to stderr")
+	fmt.Fprint(os.Stdout, "no newline")
+	os.Exit(0)
+}
+
+func TestWithAnnouncer(t *testing.T) {
+	var buf bytes.Buffer
+	p := mpb.New(
+		mpb.WithOutput(ioutil.Discard),
+		mpb.WithAnnouncer(&buf, time.Hour),
+	)
+
+	download := p.AddBar(100, mpb.BarName("download"))
+	download.IncrBy(45)
+	other := p.AddBar(10)
+	other.IncrBy(10)
+	download.Abort(false)
+
+	p.Wait()
+
+	out := buf.String()
+	if n := strings.Count(out, "download aborted\n"); n != 1 {
+		t.Errorf("expected single abort announcement, got %d: %q", n, out)
+	}
+	if n := strings.Count(out, "bar 1 complete\n"); n != 1 {
+		t.Errorf("expected single completion announcement, got %d: %q", n, out)
+	}
+}
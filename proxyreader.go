@@ -3,83 +3,194 @@ package mpb
 import (
 	"io"
 	"io/ioutil"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type proxyReader struct {
 	io.ReadCloser
 	bar *Bar
+	eof func()
 }
 
 func (x *proxyReader) Read(p []byte) (int, error) {
 	n, err := x.ReadCloser.Read(p)
 	x.bar.IncrBy(n)
 	if err == io.EOF {
-		go x.bar.SetTotal(0, true)
+		x.eof()
 	}
 	return n, err
 }
 
 type proxyWriterTo struct {
-	io.ReadCloser // *proxyReader
-	wt            io.WriterTo
-	bar           *Bar
+	*proxyReader
+	wt io.WriterTo
 }
 
 func (x *proxyWriterTo) WriteTo(w io.Writer) (int64, error) {
 	n, err := x.wt.WriteTo(w)
 	x.bar.IncrInt64(n)
-	if err == io.EOF {
-		go x.bar.SetTotal(0, true)
+	// WriteTo returns nil error, once source is drained
+	if err == nil || err == io.EOF {
+		x.eof()
 	}
 	return n, err
 }
 
 type ewmaProxyReader struct {
-	io.ReadCloser // *proxyReader
-	bar           *Bar
-	iT            time.Time
+	*proxyReader
+	clock *iterClock
 }
 
 func (x *ewmaProxyReader) Read(p []byte) (int, error) {
 	n, err := x.ReadCloser.Read(p)
 	if n > 0 {
-		x.bar.DecoratorEwmaUpdate(time.Since(x.iT))
-		x.iT = time.Now()
+		x.bar.EwmaIncrBy(n, x.clock.lap())
+	}
+	if err == io.EOF {
+		x.eof()
 	}
 	return n, err
 }
 
 type ewmaProxyWriterTo struct {
-	io.ReadCloser             // *ewmaProxyReader
-	wt            io.WriterTo // *proxyWriterTo
-	bar           *Bar
-	iT            time.Time
+	*ewmaProxyReader
+	wt io.WriterTo
 }
 
 func (x *ewmaProxyWriterTo) WriteTo(w io.Writer) (int64, error) {
 	n, err := x.wt.WriteTo(w)
 	if n > 0 {
-		x.bar.DecoratorEwmaUpdate(time.Since(x.iT))
-		x.iT = time.Now()
+		x.bar.EwmaIncrInt64(n, x.clock.lap())
+	}
+	// WriteTo returns nil error, once source is drained
+	if err == nil || err == io.EOF {
+		x.eof()
+	}
+	return n, err
+}
+
+// iterClock measures iteration durations, it may be shared among
+// several readers feeding the same bar.
+type iterClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func newIterClock() *iterClock {
+	return &iterClock{t: time.Now()}
+}
+
+func (c *iterClock) lap() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	dur := now.Sub(c.t)
+	c.t = now
+	return dur
+}
+
+type sizeHintReader struct {
+	io.ReadCloser
+	bar   *Bar
+	hint  func() int64
+	known bool
+}
+
+func (x *sizeHintReader) Read(p []byte) (int, error) {
+	n, err := x.ReadCloser.Read(p)
+	if !x.known {
+		if size := x.hint(); size > 0 {
+			x.known = true
+			x.bar.SetTotal(size, false)
+		}
+	}
+	return n, err
+}
+
+// rateLimitedReader throttles reads to rate bytes per second. Reads
+// are capped at a tenth of second worth of bytes, so progress is
+// reported smoothly rather than in one second bursts.
+type rateLimitedReader struct {
+	io.ReadCloser
+	rate  int64
+	chunk int
+	start time.Time
+	n     int64
+}
+
+func newRateLimitedReader(r io.Reader, rate int64) *rateLimitedReader {
+	chunk := rate / 10
+	if chunk < 1 {
+		chunk = 1
+	}
+	return &rateLimitedReader{
+		ReadCloser: toReadCloser(r),
+		rate:       rate,
+		chunk:      int(chunk),
+	}
+}
+
+func (x *rateLimitedReader) Read(p []byte) (int, error) {
+	if x.start.IsZero() {
+		x.start = time.Now()
+	}
+	if len(p) > x.chunk {
+		p = p[:x.chunk]
+	}
+	n, err := x.ReadCloser.Read(p)
+	x.n += int64(n)
+	due := x.start.Add(time.Duration(float64(x.n) / float64(x.rate) * float64(time.Second)))
+	if d := time.Until(due); d > 0 {
+		time.Sleep(d)
 	}
 	return n, err
 }
 
-func newProxyReader(r io.Reader, bar *Bar) io.ReadCloser {
-	rc := toReadCloser(r)
-	rc = &proxyReader{rc, bar}
+type counter struct {
+	bar   *Bar
+	clock *iterClock
+}
+
+func (x *counter) Write(p []byte) (int, error) {
+	if x.clock != nil {
+		x.bar.EwmaIncrBy(len(p), x.clock.lap())
+	} else {
+		x.bar.IncrBy(len(p))
+	}
+	return len(p), nil
+}
+
+func newProxyReader(r io.Reader, bar *Bar, clock *iterClock, eof func()) io.ReadCloser {
+	pr := &proxyReader{toReadCloser(r), bar, eof}
 
 	if wt, isWriterTo := r.(io.WriterTo); bar.hasEwmaDecorators {
-		now := time.Now()
-		rc = &ewmaProxyReader{rc, bar, now}
+		er := &ewmaProxyReader{pr, clock}
 		if isWriterTo {
-			rc = &ewmaProxyWriterTo{rc, wt, bar, now}
+			return &ewmaProxyWriterTo{er, wt}
 		}
+		return er
 	} else if isWriterTo {
-		rc = &proxyWriterTo{rc, wt, bar}
+		return &proxyWriterTo{pr, wt}
+	}
+	return pr
+}
+
+// eofCountdown returns func, which completes bar once it's been called
+// for each of n readers.
+func eofCountdown(bar *Bar, n int) func() func() {
+	remaining := int32(n)
+	return func() func() {
+		var once sync.Once
+		return func() {
+			once.Do(func() {
+				if atomic.AddInt32(&remaining, -1) == 0 {
+					go bar.SetTotal(0, true)
+				}
+			})
+		}
 	}
-	return rc
 }
 
 func toReadCloser(r io.Reader) io.ReadCloser {
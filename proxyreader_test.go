@@ -5,9 +5,13 @@ import (
 	"io"
 	"io/ioutil"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/vbauerster/mpb/v5"
+	"github.com/vbauerster/mpb/v5/decor"
 )
 
 const content = `Lorem ipsum dolor sit amet, consectetur adipisicing elit, sed do
@@ -88,3 +92,180 @@ func TestProxyWriterTo(t *testing.T) {
 		t.Errorf("Expected content: %s, got: %s\n", content, got)
 	}
 }
+
+func TestProxyReaderN(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(ioutil.Discard))
+
+	parts := 4
+	bar := p.AddBar(int64(len(content)*parts),
+		mpb.AppendDecorators(decor.EwmaSpeed(0, "%.1f", 30)),
+	)
+
+	readers := make([]io.Reader, parts)
+	for i := range readers {
+		readers[i] = &testReader{strings.NewReader(content), false}
+	}
+
+	var wg sync.WaitGroup
+	for _, r := range bar.ProxyReaderN(readers...) {
+		wg.Add(1)
+		go func(r io.ReadCloser) {
+			defer wg.Done()
+			defer r.Close()
+			if _, err := io.Copy(ioutil.Discard, r); err != nil {
+				t.Errorf("Error copying from reader: %+v\n", err)
+			}
+		}(r)
+	}
+	wg.Wait()
+
+	p.Wait()
+
+	if got := bar.Current(); got != int64(len(content)*parts) {
+		t.Errorf("Expected current: %d, got: %d\n", len(content)*parts, got)
+	}
+}
+
+func TestProxyReaderNWriterTo(t *testing.T) {
+	for _, ewma := range []bool{false, true} {
+		p := mpb.New(mpb.WithOutput(ioutil.Discard))
+
+		parts := 2
+		var options []mpb.BarOption
+		if ewma {
+			options = append(options, mpb.AppendDecorators(decor.EwmaSpeed(0, "%.1f", 30)))
+		}
+		// total is overestimated, so bar completes on EOF only
+		bar := p.AddBar(int64(len(content)*parts*10), options...)
+
+		readers := make([]io.Reader, parts)
+		for i := range readers {
+			readers[i] = strings.NewReader(content)
+		}
+		for _, r := range bar.ProxyReaderN(readers...) {
+			if _, ok := r.(io.WriterTo); !ok {
+				t.Fatalf("ewma %t: expected io.WriterTo proxy, got: %T", ewma, r)
+			}
+			if _, err := io.Copy(ioutil.Discard, r); err != nil {
+				t.Errorf("Error copying from reader: %+v\n", err)
+			}
+		}
+
+		done := make(chan struct{})
+		go func() {
+			p.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatalf("ewma %t: bar hasn't completed after all readers were drained", ewma)
+		}
+
+		if got := bar.Current(); got != int64(len(content)*parts) {
+			t.Errorf("ewma %t: expected current: %d, got: %d\n", ewma, len(content)*parts, got)
+		}
+	}
+}
+
+func TestNewCounter(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(ioutil.Discard))
+
+	workers := 4
+	bar := p.AddBar(int64(len(content)*workers),
+		mpb.AppendDecorators(decor.EwmaSpeed(0, "%.1f", 30)),
+	)
+
+	counter := bar.NewCounter()
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tr := io.TeeReader(strings.NewReader(content), counter)
+			if _, err := io.Copy(ioutil.Discard, tr); err != nil {
+				t.Errorf("Error copying from reader: %+v\n", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	p.Wait()
+
+	if !bar.Completed() {
+		t.Error("bar isn't completed")
+	}
+}
+
+func TestProxyReaderLimited(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(ioutil.Discard))
+
+	total := len(content)
+	bar := p.AddBar(int64(total), mpb.TrimSpace())
+
+	// read whole content in about 200ms
+	rate := int64(total) * 5
+
+	var buf bytes.Buffer
+	start := time.Now()
+	_, err := io.Copy(&buf, bar.ProxyReaderLimited(strings.NewReader(content), rate))
+	if err != nil {
+		t.Errorf("Error copying from reader: %+v\n", err)
+	}
+	elapsed := time.Since(start)
+
+	p.Wait()
+
+	if got := buf.String(); got != content {
+		t.Errorf("Expected content: %s, got: %s\n", content, got)
+	}
+
+	if elapsed < 150*time.Millisecond {
+		t.Errorf("Expected throttled read, took: %s\n", elapsed)
+	}
+
+	if !bar.Completed() {
+		t.Error("bar isn't completed")
+	}
+}
+
+func TestProxyReaderWithSizeHint(t *testing.T) {
+	var out bytes.Buffer
+	p := mpb.New(mpb.WithOutput(&out), mpb.WithWidth(20))
+
+	bar := p.Add(0, mpb.NewAutoFiller(nil, "", false), mpb.TrimSpace())
+
+	var size int64
+	hint := func() int64 {
+		return atomic.LoadInt64(&size)
+	}
+
+	header := make([]byte, 10)
+	pr := bar.ProxyReaderWithSizeHint(strings.NewReader(content), hint)
+	if _, err := io.ReadFull(pr, header); err != nil {
+		t.Fatalf("Error reading header: %+v\n", err)
+	}
+	if bar.Completed() {
+		t.Fatal("bar with unknown size has been completed early")
+	}
+	atomic.StoreInt64(&size, int64(len(content)))
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, pr); err != nil {
+		t.Errorf("Error copying from reader: %+v\n", err)
+	}
+
+	p.Wait()
+
+	if got := string(header) + buf.String(); got != content {
+		t.Errorf("Expected content: %s, got: %s\n", content, got)
+	}
+
+	frame := out.String()
+	if i := strings.LastIndex(frame, "\x1b[J"); i >= 0 {
+		frame = frame[i+3:]
+	}
+	if want := "[==================]"; strings.TrimSpace(frame) != want {
+		t.Errorf("Expected final frame: %q, got: %q\n", want, frame)
+	}
+}
@@ -0,0 +1,92 @@
+package mpb
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/vbauerster/mpb/v5/decor"
+)
+
+var (
+	sparkGlyphs      = []string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+	asciiSparkGlyphs = []string{"_", ".", "-", "~", "=", "+", "*", "#"}
+)
+
+// sparkline keeps speed history in a ring buffer. It's fed by bar's
+// increments and samples amount accumulated once per interval, so
+// history doesn't depend on refresh rate.
+type sparkline struct {
+	glyphs   []string
+	samples  []float64
+	next     int
+	count    int
+	interval time.Duration
+	// start of the current interval and amount accumulated within it
+	start time.Time
+	n     int64
+}
+
+func newSparkline(size int, interval time.Duration, unicode bool) *sparkline {
+	sl := &sparkline{
+		glyphs:   sparkGlyphs,
+		samples:  make([]float64, size),
+		interval: interval,
+	}
+	if !unicode {
+		sl.glyphs = asciiSparkGlyphs
+	}
+	return sl
+}
+
+// add accounts n units, incremented at now.
+func (sl *sparkline) add(n int64, now time.Time) {
+	sl.roll(now)
+	sl.n += n
+}
+
+// roll pushes speed of the current interval into the ring buffer,
+// once the interval is over.
+func (sl *sparkline) roll(now time.Time) {
+	if sl.start.IsZero() {
+		sl.start = now
+		return
+	}
+	elapsed := now.Sub(sl.start)
+	if elapsed < sl.interval {
+		return
+	}
+	sl.samples[sl.next] = float64(sl.n) / elapsed.Seconds()
+	sl.next = (sl.next + 1) % len(sl.samples)
+	if sl.count < len(sl.samples) {
+		sl.count++
+	}
+	sl.start, sl.n = now, 0
+}
+
+// Fill writes sparkline of len(sl.samples) width, oldest sample first.
+// Idle intervals are rolled in as zero speed, until bar is completed.
+func (sl *sparkline) Fill(w io.Writer, _ int, st decor.Statistics) {
+	if !st.Completed {
+		sl.roll(time.Now())
+	}
+	size := len(sl.samples)
+	var max float64
+	for _, v := range sl.samples[:sl.count] {
+		if v > max {
+			max = v
+		}
+	}
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat(" ", size-sl.count))
+	top := float64(len(sl.glyphs) - 1)
+	for i := size - sl.count; i < size; i++ {
+		v := sl.samples[(sl.next+i)%size]
+		var n int
+		if max > 0 {
+			n = int(v/max*top + 0.5)
+		}
+		sb.WriteString(sl.glyphs[n])
+	}
+	io.WriteString(w, sb.String())
+}
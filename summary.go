@@ -0,0 +1,47 @@
+package mpb
+
+import (
+	"time"
+
+	"github.com/vbauerster/mpb/v5/decor"
+)
+
+// Summary is an aggregate of all bars' statistics, added to the
+// container during its lifetime.
+type Summary struct {
+	// Bars statistics, in order bars were added.
+	Bars []decor.Statistics
+	// Current is sum of all bars' current values.
+	Current int64
+	// Total is sum of all bars' totals.
+	Total int64
+	// Completed is count of completed bars.
+	Completed int
+	// Elapsed is time passed since container has been created.
+	Elapsed time.Duration
+}
+
+// Speed returns overall average speed in units per second.
+func (s Summary) Speed() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Current) / s.Elapsed.Seconds()
+}
+
+func makeSummary(bars []*Bar, elapsed time.Duration) Summary {
+	summary := Summary{
+		Bars:    make([]decor.Statistics, 0, len(bars)),
+		Elapsed: elapsed,
+	}
+	for _, b := range bars {
+		st := b.statistics()
+		summary.Bars = append(summary.Bars, st)
+		summary.Current += st.Current
+		summary.Total += st.Total
+		if st.Completed {
+			summary.Completed++
+		}
+	}
+	return summary
+}
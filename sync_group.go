@@ -0,0 +1,42 @@
+package mpb
+
+import "sync"
+
+// SyncGroup keeps widths of synced decorator columns, i.e. ones with
+// DSyncWidth bit set, across containers, so tools which create one
+// Progress per phase get a consistent look. Column widths only grow,
+// within a group. SyncGroup is safe for concurrent use by several
+// containers. Pass it to containers via WithSyncGroup option.
+type SyncGroup struct {
+	mu      sync.Mutex
+	pWidths map[int]int
+	aWidths map[int]int
+}
+
+// NewSyncGroup creates new SyncGroup.
+func NewSyncGroup() *SyncGroup {
+	return &SyncGroup{
+		pWidths: make(map[int]int),
+		aWidths: make(map[int]int),
+	}
+}
+
+// prependFit and appendFit return width of column, which is max of
+// width and widest seen so far for the same column.
+func (g *SyncGroup) prependFit(column, width int) int {
+	return g.fit(g.pWidths, column, width)
+}
+
+func (g *SyncGroup) appendFit(column, width int) int {
+	return g.fit(g.aWidths, column, width)
+}
+
+func (g *SyncGroup) fit(widths map[int]int, column, width int) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if w := widths[column]; w > width {
+		return w
+	}
+	widths[column] = width
+	return width
+}
@@ -0,0 +1,43 @@
+package mpb
+
+// BarTemplate is a reusable bar definition, see NewBarTemplate.
+type BarTemplate struct {
+	filler  func() BarFiller
+	options []func(name string) BarOption
+}
+
+// NewBarTemplate creates BarTemplate, so many identically configured
+// bars can be added with *Progress.AddFromTemplate, without repeating
+// option code. Decorators and fillers are stateful, so they can't be
+// shared among bars, that's why template holds funcs, which are called
+// for every bar to make fresh instances. Presets, like
+// PresetDownloadBar, fit as option funcs as is.
+//
+//	`filler` func to make bar's filler, nil for default bar filler
+//
+//	`options` funcs to make bar's options, called with bar's name
+//
+func NewBarTemplate(filler func() BarFiller, options ...func(name string) BarOption) *BarTemplate {
+	if filler == nil {
+		filler = func() BarFiller {
+			return NewBarFiller(DefaultBarStyle, false)
+		}
+	}
+	return &BarTemplate{
+		filler:  filler,
+		options: options,
+	}
+}
+
+// AddFromTemplate creates a new bar out of tmpl and adds it to the
+// rendering queue. Bar's name is set via BarName option, options are
+// applied after template's ones.
+func (p *Progress) AddFromTemplate(tmpl *BarTemplate, total int64, name string, options ...BarOption) *Bar {
+	opts := make([]BarOption, 0, len(tmpl.options)+len(options)+1)
+	opts = append(opts, BarName(name))
+	for _, fn := range tmpl.options {
+		opts = append(opts, fn(name))
+	}
+	opts = append(opts, options...)
+	return p.Add(total, tmpl.filler(), opts...)
+}
@@ -0,0 +1,65 @@
+package mpb
+
+import (
+	"fmt"
+	"io"
+)
+
+const (
+	oscTitle     = "\x1b]2;%s\x07"
+	oscTaskbar   = "\x1b]9;4;%d;%d\x07"
+	csiPushTitle = "\x1b[22;2t"
+	csiPopTitle  = "\x1b[23;2t"
+)
+
+// termProgress reports overall progress out of band, i.e. into
+// terminal's title or taskbar, so minimized terminals still convey
+// progress.
+type termProgress struct {
+	titleFormat string
+	title       bool
+	taskbar     bool
+	started     bool
+	last        int
+}
+
+func (tp *termProgress) write(w io.Writer, current, total int64) {
+	percent := -1
+	if total > 0 {
+		percent = int(100 * current / total)
+		if percent > 100 {
+			percent = 100
+		}
+	}
+	if tp.started && percent == tp.last {
+		return
+	}
+	if !tp.started && tp.title {
+		io.WriteString(w, csiPushTitle)
+	}
+	tp.started = true
+	tp.last = percent
+	if tp.title && percent >= 0 {
+		fmt.Fprintf(w, oscTitle, fmt.Sprintf(tp.titleFormat, percent))
+	}
+	if tp.taskbar {
+		if percent < 0 {
+			// indeterminate state
+			fmt.Fprintf(w, oscTaskbar, 3, 0)
+		} else {
+			fmt.Fprintf(w, oscTaskbar, 1, percent)
+		}
+	}
+}
+
+func (tp *termProgress) finish(w io.Writer) {
+	if !tp.started {
+		return
+	}
+	if tp.title {
+		io.WriteString(w, csiPopTitle)
+	}
+	if tp.taskbar {
+		fmt.Fprintf(w, oscTaskbar, 0, 0)
+	}
+}
@@ -0,0 +1,60 @@
+package mpb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/vbauerster/mpb/v5/cwriter"
+)
+
+func TestTermProgress(t *testing.T) {
+	s := new(pState)
+	WithTitleProgress("copy %d%%")(s)
+	WithTaskbarProgress()(s)
+	tp := s.termProgress
+
+	var buf bytes.Buffer
+	tp.write(&buf, 42, 100)
+	want := csiPushTitle + "\x1b]2;copy 42%\x07" + "\x1b]9;4;1;42\x07"
+	if got := buf.String(); got != want {
+		t.Errorf("want: %q, got: %q\n", want, got)
+	}
+
+	buf.Reset()
+	tp.write(&buf, 42, 100)
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing on unchanged percentage, got: %q\n", buf.String())
+	}
+
+	buf.Reset()
+	tp.finish(&buf)
+	want = csiPopTitle + "\x1b]9;4;0;0\x07"
+	if got := buf.String(); got != want {
+		t.Errorf("want: %q, got: %q\n", want, got)
+	}
+}
+
+func TestTermProgressEndsWithNewline(t *testing.T) {
+	s := new(pState)
+	WithTitleProgress("copy %d%%")(s)
+	WithTaskbarProgress()(s)
+
+	var buf bytes.Buffer
+	cw := cwriter.New(&buf)
+	cw.WriteString("bar\n")
+	s.termProgress.write(cw, 42, 100)
+	if err := cw.Flush(1); err != nil {
+		t.Fatal(err)
+	}
+	if !cw.EndsWithNewline() {
+		t.Errorf("expected trailing title and taskbar sequences to be ignored, output: %q", buf.String())
+	}
+
+	cw.WriteString("no newline\x1b[0m")
+	if err := cw.Flush(1); err != nil {
+		t.Fatal(err)
+	}
+	if cw.EndsWithNewline() {
+		t.Errorf("expected line without newline to be detected, output: %q", buf.String())
+	}
+}
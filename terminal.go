@@ -0,0 +1,58 @@
+package mpb
+
+import (
+	"io"
+
+	"github.com/vbauerster/mpb/v5/internal/term"
+)
+
+// Terminal describes capabilities of an output, which progress
+// container renders to.
+type Terminal struct {
+	// IsTTY reports whether output is a terminal.
+	IsTTY bool
+	// Colors is color depth, terminal advertises support of.
+	Colors ColorDepth
+	// Unicode reports whether terminal is expected to render unicode.
+	Unicode bool
+	// VT reports whether terminal processes virtual terminal
+	// sequences. On windows detection enables VT processing, if
+	// possible.
+	VT bool
+}
+
+// TerminalAdapter interface.
+// BarFiller or Decorator may implement this interface in order to
+// adapt its output to capabilities of the terminal, for example to
+// fall back to ASCII or to drop colors. Built-in fillers implement it.
+type TerminalAdapter interface {
+	AdaptTerminal(Terminal)
+}
+
+// DetectTerminal detects capabilities of w. If w is not a terminal,
+// colors are disabled and unicode is assumed, so output written to
+// files or buffers stays unchanged.
+func DetectTerminal(w io.Writer) Terminal {
+	fd, ok := term.Fd(w)
+	if !ok {
+		return Terminal{Unicode: true}
+	}
+	t := Terminal{
+		IsTTY:   true,
+		Unicode: term.Unicode(),
+		VT:      term.EnableVT(fd),
+	}
+	if t.VT {
+		t.Colors = ColorDepth(term.ColorDepth())
+	}
+	return t
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}